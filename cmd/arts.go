@@ -35,6 +35,12 @@ var artsCmd = &cobra.Command{
 		}
 
 		client := tpclient.NewClient(baseURL, token, verboseVal)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
 
 		items, err := client.List("AgileReleaseTrains", artsWhere, artsFields, artsTake, artsSkip)
 		if err != nil {
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/shalomb/tpcli/pkg/tpclient"
+	"github.com/spf13/viper"
+)
+
+// configureAuthenticator overrides client's default query-token
+// Authenticator based on the auth.mode config key. Supported modes are
+// "basic", "bearer" and "cookie"; "query" or an unset mode leaves the
+// client's default query-token authentication in place, and any other value
+// is an error.
+//
+// auth.mode config keys:
+//
+//	auth.mode            basic | bearer | cookie
+//	auth.username        (basic)
+//	auth.password        (basic)
+//	auth.token           (bearer)
+//	auth.cookie_name     (cookie)
+//	auth.cookie_value    (cookie)
+func configureAuthenticator(client *tpclient.Client) error {
+	switch mode := viper.GetString("auth.mode"); mode {
+	case "", "query":
+		// default query-token authentication set by tpclient.NewClient
+	case "basic":
+		client.SetAuthenticator(tpclient.NewBasicAuthenticator(
+			viper.GetString("auth.username"),
+			viper.GetString("auth.password"),
+		))
+	case "bearer":
+		client.SetAuthenticator(tpclient.NewBearerAuthenticator(viper.GetString("auth.token")))
+	case "cookie":
+		client.SetAuthenticator(tpclient.NewCookieAuthenticator(
+			viper.GetString("auth.cookie_name"),
+			viper.GetString("auth.cookie_value"),
+		))
+	default:
+		return fmt.Errorf("unsupported auth.mode %q (want basic, bearer or cookie)", mode)
+	}
+	return nil
+}
+
+// authRequiresToken reports whether the configured auth.mode needs a
+// --token/TP_TOKEN value, as opposed to basic/bearer/cookie credentials
+// supplied some other way via the auth.* config keys.
+func authRequiresToken() bool {
+	switch viper.GetString("auth.mode") {
+	case "", "query":
+		return true
+	default:
+		return false
+	}
+}
@@ -1,14 +1,24 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"os"
+	"sort"
+	"time"
 
+	"github.com/shalomb/tpcli/pkg/schema"
 	"github.com/shalomb/tpcli/pkg/tpclient"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var (
+	discoverRefresh    bool
+	discoverSchemaFile string
+	discoverDepth      int
+)
+
 var discoverCmd = &cobra.Command{
 	Use:   "discover",
 	Short: "Discover TargetProcess entity types and structure",
@@ -21,11 +31,22 @@ This command connects to your TargetProcess instance and shows:
 - What fields/properties are available for each type
 - Sample data from each type
 
+The findings are cached as a schema file (default ~/.cache/tpcli/schema.json,
+override with --schema-file) that 'tpcli get' and 'tpcli list' use to
+validate --fields/--where and power shell completion. If a cache already
+exists, discover prints it instead of rescanning; pass --refresh to force a
+new scan. --depth sets how many levels of entity types discover scans:
+1 (the default) scans only the top-level types, and each level above that
+follows relationship fields (e.g. Project.Process) one more hop to capture
+nested entity types.
+
 Use this to discover what entity types you can query with 'tpcli list'.
 
 Examples:
-  tpcli discover           # Full discovery with sample data
-  tpcli discover -v        # Verbose output with additional details
+  tpcli discover              # Full discovery with sample data
+  tpcli discover -v           # Verbose output with additional details
+  tpcli discover --refresh    # Rescan even if a schema cache exists
+  tpcli discover --depth 2    # Also discover entity types reachable via relationship fields
 
 After discovering, use entity types with 'tpcli list':
   tpcli list Features
@@ -36,15 +57,40 @@ After discovering, use entity types with 'tpcli list':
 		baseURL := viper.GetString("url")
 		verboseVal := viper.GetBool("verbose")
 
-		if token == "" {
+		if token == "" && authRequiresToken() {
 			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
 		}
 		if baseURL == "" {
 			return fmt.Errorf("base URL is required (use --url, TP_URL env var, or config file)")
 		}
 
+		schemaPath := discoverSchemaFile
+		if schemaPath == "" {
+			var err error
+			schemaPath, err = schema.DefaultPath()
+			if err != nil {
+				return fmt.Errorf("resolving schema cache path: %w", err)
+			}
+		}
+
+		if !discoverRefresh {
+			if cached, err := schema.Load(schemaPath); err == nil {
+				fmt.Printf("Using cached schema from %s (pass --refresh to rescan)\n\n", schemaPath)
+				return printDiscovered(entityTypeSchemasToDiscovered(cached))
+			}
+		}
+
 		// Create client
 		client := tpclient.NewClient(baseURL, token, verboseVal)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
+
+		ctx, stop := signalContext()
+		defer stop()
 
 		// Try basic entity introspection
 		fmt.Println("Attempting to discover TargetProcess instance...")
@@ -63,63 +109,142 @@ After discovering, use entity types with 'tpcli list':
 			"Teams",
 		}
 
-		discovered := make(map[string]interface{})
+		sch := &schema.Schema{EntityTypes: map[string]schema.EntityTypeSchema{}}
+		visited := map[string]bool{}
 
 		for _, entityType := range entityTypes {
-			fmt.Printf("Discovering %s...", entityType)
-
-			// Try to fetch just one item to see the structure
-			items, err := client.List(entityType, "", []string{}, 1, 0)
-			if err != nil {
-				fmt.Printf(" ✗ Error: %v\n", err)
-				discovered[entityType] = map[string]interface{}{
-					"status": "error",
-					"error":  err.Error(),
-				}
-				continue
-			}
-
-			if len(items) == 0 {
-				fmt.Println(" (empty)")
-				discovered[entityType] = map[string]interface{}{
-					"status": "ok",
-					"count":  0,
-					"sample": nil,
-				}
-				continue
+			if ctx.Err() != nil {
+				fmt.Println("Discovery interrupted")
+				break
 			}
+			discoverEntityType(ctx, client, entityType, 1, discoverDepth, sch, visited)
+		}
 
-			fmt.Printf(" ✓ Found %d items\n", len(items))
-
-			// Extract field names from first item
-			var fields []string
-			item := items[0]
-			for key := range item {
-				fields = append(fields, key)
-			}
+		sch.GeneratedAt = time.Now().Format(time.RFC3339)
 
-			discovered[entityType] = map[string]interface{}{
-				"status": "ok",
-				"count":  len(items),
-				"fields": fields,
-				"sample": items[0],
-			}
+		if err := schema.Save(schemaPath, sch); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to cache schema: %v\n", err)
+		} else {
+			fmt.Printf("\nSchema cached to %s\n", schemaPath)
 		}
 
 		fmt.Println()
 		fmt.Println("=== Discovery Results ===")
 		fmt.Println()
 
-		output, err := json.MarshalIndent(discovered, "", "  ")
-		if err != nil {
-			return fmt.Errorf("formatting output: %w", err)
+		return printDiscovered(entityTypeSchemasToDiscovered(sch))
+	},
+}
+
+// discoverEntityType fetches one sample item of entityType, records its
+// shape into sch, and - while depth allows and the related type hasn't
+// already been visited - recurses into any nested relationship fields
+// (object values carrying a TargetProcess "ResourceType").
+func discoverEntityType(ctx context.Context, client *tpclient.Client, entityType string, depth, maxDepth int, sch *schema.Schema, visited map[string]bool) {
+	if visited[entityType] || ctx.Err() != nil {
+		return
+	}
+	visited[entityType] = true
+
+	fmt.Printf("Discovering %s...", entityType)
+
+	items, err := client.ListContext(ctx, entityType, "", []string{}, 1, 0)
+	if err != nil {
+		fmt.Printf(" ✗ Error: %v\n", err)
+		sch.EntityTypes[entityType] = schema.EntityTypeSchema{}
+		return
+	}
+
+	if len(items) == 0 {
+		fmt.Println(" (empty)")
+		sch.EntityTypes[entityType] = schema.EntityTypeSchema{Count: 0, Fields: map[string]schema.FieldSchema{}}
+		return
+	}
+
+	fmt.Printf(" ✓ Found %d items\n", len(items))
+
+	item := items[0]
+	fields := make(map[string]schema.FieldSchema, len(item))
+	for key, v := range item {
+		fields[key] = schema.InferField(v)
+	}
+	sch.EntityTypes[entityType] = schema.EntityTypeSchema{Count: len(items), Fields: fields}
+
+	if depth >= maxDepth {
+		return
+	}
+	for _, fs := range fields {
+		if fs.EntityType != "" && !visited[fs.EntityType] {
+			discoverEntityType(ctx, client, fs.EntityType, depth+1, maxDepth, sch, visited)
 		}
+	}
+}
 
-		fmt.Println(string(output))
-		return nil
-	},
+// entityTypeSchemasToDiscovered renders a Schema back into the
+// status/count/fields map the 'discover' command has always printed, so
+// cached and freshly-scanned output look the same.
+func entityTypeSchemasToDiscovered(sch *schema.Schema) map[string]interface{} {
+	discovered := make(map[string]interface{}, len(sch.EntityTypes))
+	for entityType, et := range sch.EntityTypes {
+		fields := make([]string, 0, len(et.Fields))
+		for name := range et.Fields {
+			fields = append(fields, name)
+		}
+		discovered[entityType] = map[string]interface{}{
+			"status": "ok",
+			"count":  et.Count,
+			"fields": fields,
+		}
+	}
+	return discovered
+}
+
+func printDiscovered(discovered map[string]interface{}) error {
+	f, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	data, fields := discoveredOutputData(discovered)
+	if err := f.Format(os.Stdout, data, fields); err != nil {
+		return fmt.Errorf("formatting output: %w", err)
+	}
+	return nil
+}
+
+// discoveredOutputData adapts discovered for the current --output format.
+// csv/table can only render a flat list of scalar-valued rows, so
+// discovered (entityType -> {status,count,fields}) is flattened into one
+// row per entity type with an added entity_type column; every other format
+// (json, jsonl, yaml, template) renders discovered's entityType-keyed map
+// as-is, unchanged from how 'discover' has always printed it.
+func discoveredOutputData(discovered map[string]interface{}) (interface{}, []string) {
+	if outputFormat != "csv" && outputFormat != "table" {
+		return discovered, nil
+	}
+
+	entityTypes := make([]string, 0, len(discovered))
+	for entityType := range discovered {
+		entityTypes = append(entityTypes, entityType)
+	}
+	sort.Strings(entityTypes)
+
+	rows := make([]map[string]interface{}, 0, len(discovered))
+	for _, entityType := range entityTypes {
+		row := map[string]interface{}{"entity_type": entityType}
+		if info, ok := discovered[entityType].(map[string]interface{}); ok {
+			for k, v := range info {
+				row[k] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, []string{"entity_type", "status", "count", "fields"}
 }
 
 func init() {
 	rootCmd.AddCommand(discoverCmd)
+
+	discoverCmd.Flags().BoolVar(&discoverRefresh, "refresh", false, "Rescan even if a schema cache already exists")
+	discoverCmd.Flags().StringVar(&discoverSchemaFile, "schema-file", "", "Path to the schema cache file (default ~/.cache/tpcli/schema.json)")
+	discoverCmd.Flags().IntVar(&discoverDepth, "depth", 1, "Levels of entity types to discover: 1 scans top-level types only, each further level follows relationship fields one more hop")
 }
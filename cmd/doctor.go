@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/shalomb/tpcli/pkg/tpclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check connectivity and credentials against a TargetProcess instance",
+	Long: `Check connectivity and credentials against a TargetProcess instance.
+
+Probes the configured base URL's Context endpoint and reports whether
+tpcli can reach it, what server version it's running, and whether the
+configured credentials are valid.
+
+Example:
+  tpcli doctor`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = viper.GetString("token")
+		}
+
+		baseURL, _ := cmd.Flags().GetString("url")
+		if baseURL == "" {
+			baseURL = viper.GetString("url")
+		}
+
+		verboseFlag, _ := cmd.Flags().GetBool("verbose")
+		verbose := verboseFlag || viper.GetBool("verbose")
+
+		if token == "" && authRequiresToken() {
+			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
+		}
+		if baseURL == "" {
+			return fmt.Errorf("base URL is required (use --url, TP_URL env var, or config file)")
+		}
+
+		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
+
+		fmt.Printf("Checking %s...\n", baseURL)
+
+		info, err := client.ServerInfo()
+		if err != nil {
+			fmt.Printf("✗ Could not reach %s or validate credentials: %v\n", baseURL, err)
+			return fmt.Errorf("doctor check failed: %w", err)
+		}
+
+		fmt.Printf("✓ Connected to %s\n", baseURL)
+		if info.Version != "" {
+			fmt.Printf("✓ Server version: %s\n", info.Version)
+		}
+		if info.Identity != "" {
+			fmt.Printf("✓ Authenticated as: %s\n", info.Identity)
+		}
+		fmt.Println("✓ Credentials are valid")
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
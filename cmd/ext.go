@@ -4,72 +4,191 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 
+	"github.com/shalomb/tpcli/pkg/extplugin"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var extCmd = &cobra.Command{
-	Use:   "ext <list|tool> [args...]",
+	Use:   "ext <list|install|tool> [args...]",
 	Short: "Run or list external extension scripts",
 	Long: `Manage external extension scripts (e.g., art-dashboard, team-deep-dive).
 
+Extensions are discovered from PATH and ~/.config/tpcli/plugins/, either as
+a tpcli-ext-<name> executable or as any executable accompanied by a
+<name>.tpcli-plugin.yaml manifest.
+
 Examples:
   tpcli ext list
-  tpcli ext team-deep-dive --team "Cloud Enablement & Delivery"`,
+  tpcli ext team-deep-dive --team "Cloud Enablement & Delivery"
+  tpcli ext install https://example.com/tpcli-ext-art-dashboard --checksum <sha256>`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if args[0] == "list" {
-			// Logical grouping for discoverability (collate by type: art-*, pi-*, team-*)
-			candidates := []string{
-				// ART-level scripts
-				"art-dashboard",
-				// PI/Release-level scripts
-				"pi-objectives",
-				"pi-status",
-				// Team-level scripts
-				"team-analysis",
-				// Legacy aliases for backwards compatibility
-				"objective-deep-dive",
-				"release-status",
-				"team-deep-dive",
-			}
-			found := []string{}
-			for _, name := range candidates {
-				if p, err := exec.LookPath(name); err == nil {
-					found = append(found, fmt.Sprintf("%s -> %s", name, p))
-				}
-			}
-			if len(found) == 0 {
-				fmt.Println("No extensions found in PATH. Install tools to ~/.local/bin or ensure they are in PATH.")
-				return nil
-			}
-			for _, f := range found {
-				fmt.Println(f)
+		switch args[0] {
+		case "list":
+			return runExtList()
+		case "install":
+			return runExtInstall(cmd, args[1:])
+		default:
+			return runExtTool(args[0], args[1:])
+		}
+	},
+}
+
+var extInstallChecksum string
+
+func init() {
+	extCmd.Flags().StringVar(&extInstallChecksum, "checksum", "", "expected sha256 checksum of the downloaded extension")
+	rootCmd.AddCommand(extCmd)
+}
+
+// pluginDirs returns the directories extensions are discovered from, PATH
+// entries first so they can shadow the global plugin directory.
+func pluginDirs() []string {
+	dirs := filepath.SplitList(os.Getenv("PATH"))
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".config", "tpcli", "plugins"))
+	}
+	return dirs
+}
+
+// categoryOrder is the display order for tpcli ext list. Plugins with no
+// category are grouped under "Other"; "Other" and any category not listed
+// here follow these, in no particular order among themselves.
+var categoryOrder = []string{"ART", "PI", "Team"}
+
+func runExtList() error {
+	plugins, err := extplugin.Discover(pluginDirs())
+	if err != nil {
+		return fmt.Errorf("discovering extensions: %w", err)
+	}
+	if len(plugins) == 0 {
+		fmt.Println("No extensions found in PATH or ~/.config/tpcli/plugins/.")
+		return nil
+	}
+
+	byCategory := map[string][]extplugin.Plugin{}
+	for _, p := range plugins {
+		category := p.Category
+		if category == "" {
+			category = "Other"
+		}
+		byCategory[category] = append(byCategory[category], p)
+	}
+
+	order := append([]string{}, categoryOrder...)
+	for category := range byCategory {
+		found := false
+		for _, c := range order {
+			if c == category {
+				found = true
+				break
 			}
-			return nil
 		}
+		if !found {
+			order = append(order, category)
+		}
+	}
 
-		tool := args[0]
-		toolArgs := args[1:]
-		path, err := exec.LookPath(tool)
-		if err != nil {
-			return fmt.Errorf("extension not found in PATH: %s", tool)
+	for _, category := range order {
+		group := byCategory[category]
+		if len(group) == 0 {
+			continue
 		}
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
 
-		c := exec.Command(path, toolArgs...)
-		c.Stdin = os.Stdin
-		c.Stdout = os.Stdout
-		c.Stderr = os.Stderr
-		if err := c.Run(); err != nil {
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				return fmt.Errorf("command exited with status %d", exitErr.ExitCode())
+		fmt.Printf("%s\n", category)
+		for _, p := range group {
+			if p.Description != "" {
+				fmt.Printf("  %-24s %s\n", p.Name, p.Description)
+			} else {
+				fmt.Printf("  %-24s %s\n", p.Name, p.Path)
 			}
-			return fmt.Errorf("running extension: %w", err)
 		}
-		return nil
-	},
+	}
+
+	return nil
 }
 
-func init() {
-	rootCmd.AddCommand(extCmd)
+func runExtInstall(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: tpcli ext install <url> --checksum <sha256>")
+	}
+	if extInstallChecksum == "" {
+		return fmt.Errorf("--checksum is required")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("locating home directory: %w", err)
+	}
+	destDir := filepath.Join(home, ".config", "tpcli", "plugins")
+
+	dest, err := extplugin.Install(args[0], extInstallChecksum, destDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Installed %s\n", dest)
+	return nil
+}
+
+func runExtTool(name string, toolArgs []string) error {
+	plugins, err := extplugin.Discover(pluginDirs())
+	if err != nil {
+		return fmt.Errorf("discovering extensions: %w", err)
+	}
+
+	var path string
+	for _, p := range plugins {
+		if p.Name == name {
+			path = p.Path
+			break
+		}
+	}
+	if path == "" {
+		// Fall back to a plain PATH lookup, so scripts that aren't
+		// registered as tpcli extensions still run like before.
+		path, err = exec.LookPath(name)
+		if err != nil {
+			return fmt.Errorf("extension not found: %s", name)
+		}
+	}
+
+	jsonR, jsonW, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("creating extension event pipe: %w", err)
+	}
+
+	c := exec.Command(path, toolArgs...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"TP_TOKEN="+viper.GetString("token"),
+		"TP_URL="+viper.GetString("url"),
+		fmt.Sprintf("TPCLI_JSON_FDS=%d", 3+len(c.ExtraFiles)),
+	)
+	c.ExtraFiles = append(c.ExtraFiles, jsonW)
+
+	events := make(chan error, 1)
+	go func() {
+		defer jsonR.Close()
+		events <- extplugin.StreamEvents(jsonR, os.Stdout)
+	}()
+
+	runErr := c.Run()
+	jsonW.Close()
+	<-events
+
+	if runErr != nil {
+		if exitErr, ok := runErr.(*exec.ExitError); ok {
+			return fmt.Errorf("command exited with status %d", exitErr.ExitCode())
+		}
+		return fmt.Errorf("running extension: %w", runErr)
+	}
+	return nil
 }
@@ -1,8 +1,8 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/shalomb/tpcli/pkg/tpclient"
@@ -22,8 +22,10 @@ var getCmd = &cobra.Command{
 Examples:
   tpcli get UserStory 12345
   tpcli get Bug 67890 --fields Id,Name,Description,EntityState
-  tpcli get Task 111 --fields Id,Name,Project,AssignedUser`,
-	Args: cobra.ExactArgs(2),
+  tpcli get Task 111 --fields Id,Name,Project,AssignedUser
+  tpcli get Bug 67890 --fields Id,Name -o table`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeEntityTypes,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		entityType := args[0]
 		id, err := strconv.Atoi(args[1])
@@ -31,6 +33,10 @@ Examples:
 			return fmt.Errorf("invalid ID: %s", args[1])
 		}
 
+		if err := validateFields(loadCachedSchema(), entityType, getFields); err != nil {
+			return err
+		}
+
 		// Get configuration - use flags first, then viper fallback
 		token, _ := cmd.Flags().GetString("token")
 		if token == "" {
@@ -45,7 +51,7 @@ Examples:
 		verboseFlag, _ := cmd.Flags().GetBool("verbose")
 		verbose := verboseFlag || viper.GetBool("verbose")
 
-		if token == "" {
+		if token == "" && authRequiresToken() {
 			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
 		}
 		if baseURL == "" {
@@ -54,6 +60,12 @@ Examples:
 
 		// Create client
 		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
 
 		// Get entity
 		item, err := client.Get(entityType, id, getFields)
@@ -61,13 +73,14 @@ Examples:
 			return fmt.Errorf("getting %s %d: %w", entityType, id, err)
 		}
 
-		// Output as JSON
-		output, err := json.MarshalIndent(item, "", "  ")
+		f, err := newFormatter()
 		if err != nil {
+			return err
+		}
+		if err := f.Format(os.Stdout, item, getFields); err != nil {
 			return fmt.Errorf("formatting output: %w", err)
 		}
 
-		fmt.Println(string(output))
 		return nil
 	},
 }
@@ -76,4 +89,5 @@ func init() {
 	rootCmd.AddCommand(getCmd)
 
 	getCmd.Flags().StringSliceVar(&getFields, "fields", []string{}, "Fields to include (comma-separated)")
+	getCmd.RegisterFlagCompletionFunc("fields", completeFields)
 }
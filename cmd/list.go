@@ -1,8 +1,8 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/shalomb/tpcli/pkg/tpclient"
 	"github.com/spf13/cobra"
@@ -10,10 +10,12 @@ import (
 )
 
 var (
-	listWhere  string
-	listFields []string
-	listTake   int
-	listSkip   int
+	listWhere    string
+	listFields   []string
+	listTake     int
+	listSkip     int
+	listAll      bool
+	listPageSize int
 )
 
 var listCmd = &cobra.Command{
@@ -25,11 +27,22 @@ Examples:
   tpcli list UserStories
   tpcli list UserStories --where "EntityState.Name eq 'Open'"
   tpcli list Bugs --fields Id,Name,EntityState --take 10
-  tpcli list Tasks --where "Project.Id eq 1234" --take 20`,
-	Args: cobra.ExactArgs(1),
+  tpcli list Tasks --where "Project.Id eq 1234" --take 20
+  tpcli list Bugs --fields Id,Name,EntityState -o csv > bugs.csv
+  tpcli list UserStories --all --page-size 100 > all-stories.json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeEntityTypes,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		entityType := args[0]
 
+		sch := loadCachedSchema()
+		if err := validateFields(sch, entityType, listFields); err != nil {
+			return err
+		}
+		if err := validateWhere(sch, entityType, listWhere); err != nil {
+			return err
+		}
+
 		// Get configuration - use flags first, then viper fallback
 		token, _ := cmd.Flags().GetString("token")
 		if token == "" {
@@ -44,7 +57,7 @@ Examples:
 		verboseFlag, _ := cmd.Flags().GetBool("verbose")
 		verbose := verboseFlag || viper.GetBool("verbose")
 
-		if token == "" {
+		if token == "" && authRequiresToken() {
 			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
 		}
 		if baseURL == "" {
@@ -53,20 +66,36 @@ Examples:
 
 		// Create client
 		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
 
-		// List entities
-		items, err := client.List(entityType, listWhere, listFields, listTake, listSkip)
-		if err != nil {
-			return fmt.Errorf("listing %s: %w", entityType, err)
+		ctx, stop := signalContext()
+		defer stop()
+
+		// List entities, walking every page when --all is set
+		var items []map[string]interface{}
+		var listErr error
+		if listAll {
+			items, listErr = client.ListAllContext(ctx, entityType, listWhere, listFields, listPageSize)
+		} else {
+			items, listErr = client.ListContext(ctx, entityType, listWhere, listFields, listTake, listSkip)
+		}
+		if listErr != nil {
+			return fmt.Errorf("listing %s: %w", entityType, listErr)
 		}
 
-		// Output as JSON
-		output, err := json.MarshalIndent(items, "", "  ")
+		f, err := newFormatter()
 		if err != nil {
+			return err
+		}
+		if err := f.Format(os.Stdout, items, listFields); err != nil {
 			return fmt.Errorf("formatting output: %w", err)
 		}
 
-		fmt.Println(string(output))
 		return nil
 	},
 }
@@ -76,6 +105,9 @@ func init() {
 
 	listCmd.Flags().StringVar(&listWhere, "where", "", "Filter expression (e.g., 'EntityState.Name eq \"Open\"')")
 	listCmd.Flags().StringSliceVar(&listFields, "fields", []string{}, "Fields to include (comma-separated)")
+	listCmd.RegisterFlagCompletionFunc("fields", completeFields)
 	listCmd.Flags().IntVar(&listTake, "take", 25, "Number of items to retrieve")
 	listCmd.Flags().IntVar(&listSkip, "skip", 0, "Number of items to skip")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "Retrieve every matching entity, paging automatically (ignores --take/--skip)")
+	listCmd.Flags().IntVar(&listPageSize, "page-size", 100, "Page size used when --all is set")
 }
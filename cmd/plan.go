@@ -3,11 +3,17 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/shalomb/tpcli/pkg/plansync"
 	"github.com/shalomb/tpcli/pkg/tpclient"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 var planCmd = &cobra.Command{
@@ -17,7 +23,8 @@ var planCmd = &cobra.Command{
 
 Examples:
   tpcli plan create TeamPIObjective --data '{"name":"Q1 Planning","team_id":1935991,...}'
-  tpcli plan update TeamPIObjective 12345 --data '{"effort":40}'`,
+  tpcli plan update TeamPIObjective 12345 --data '{"effort":40}'
+  tpcli plan apply --file manifest.yaml --parallelism 8`,
 }
 
 var createCmd = &cobra.Command{
@@ -60,6 +67,12 @@ Examples:
 
 		// Create client
 		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
 
 		// Create entity
 		result, err := client.Create(entityType, []byte(data))
@@ -124,6 +137,12 @@ Examples:
 
 		// Create client
 		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
 
 		// Update entity
 		result, err := client.Update(entityType, id, []byte(data))
@@ -147,6 +166,316 @@ Examples:
 	},
 }
 
+// planApplyItem is one record of an `apply` manifest. Op selects the
+// operation: "create" (default when ID is empty), "update" (default when ID
+// is set) or "delete" (always explicit, since an ID alone is ambiguous).
+// IdempotencyKey overrides the key tpclient would otherwise derive
+// automatically, for manifests that need a stable key across retried runs.
+type planApplyItem struct {
+	Type           string                 `json:"type" yaml:"type"`
+	ID             string                 `json:"id,omitempty" yaml:"id,omitempty"`
+	Op             string                 `json:"op,omitempty" yaml:"op,omitempty"`
+	Data           map[string]interface{} `json:"data" yaml:"data"`
+	IdempotencyKey string                 `json:"idempotency_key,omitempty" yaml:"idempotency_key,omitempty"`
+}
+
+// op resolves the item's effective operation.
+func (item planApplyItem) op() string {
+	if item.Op != "" {
+		return item.Op
+	}
+	if item.ID == "" {
+		return "create"
+	}
+	return "update"
+}
+
+// parsePlanApplyManifest parses an `apply` manifest, trying JSON first since
+// every JSON document round-trips through the YAML parser but not vice versa.
+func parsePlanApplyManifest(data []byte) ([]planApplyItem, error) {
+	var items []planApplyItem
+	if err := json.Unmarshal(data, &items); err == nil {
+		return items, nil
+	}
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return items, nil
+}
+
+// planApplyChange is a fully-resolved manifest item, ready to dispatch (or
+// print, for --dry-run): Data has already been narrowed to just the changed
+// fields when --diff is set.
+type planApplyChange struct {
+	Op             string                 `json:"op"`
+	Type           string                 `json:"type"`
+	ID             string                 `json:"id,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+	IdempotencyKey string                 `json:"idempotency_key,omitempty"`
+}
+
+// resolvePlanApplyChanges turns manifest items into planApplyChange values,
+// narrowing an update's Data to only the fields that actually differ from
+// the server's current state when diff is true. A resolved change with no
+// remaining Data fields (nothing changed) is dropped, and skipped is
+// incremented for it.
+func resolvePlanApplyChanges(client *tpclient.Client, items []planApplyItem, diff bool) (changes []planApplyChange, skipped int, err error) {
+	for _, item := range items {
+		change := planApplyChange{Op: item.op(), Type: item.Type, ID: item.ID, Data: item.Data, IdempotencyKey: item.IdempotencyKey}
+
+		if change.Op == "update" && diff {
+			id, convErr := strconv.Atoi(item.ID)
+			if convErr != nil {
+				return nil, skipped, fmt.Errorf("invalid ID %q for %s: %w", item.ID, item.Type, convErr)
+			}
+			current, getErr := client.Get(item.Type, id, nil)
+			if getErr != nil {
+				return nil, skipped, fmt.Errorf("fetching current state of %s %s: %w", item.Type, item.ID, getErr)
+			}
+			change.Data = diffFields(current, item.Data)
+			if len(change.Data) == 0 {
+				skipped++
+				continue
+			}
+		}
+
+		changes = append(changes, change)
+	}
+	return changes, skipped, nil
+}
+
+// diffFields returns the subset of wanted whose values differ from (or are
+// absent from) current.
+func diffFields(current, wanted map[string]interface{}) map[string]interface{} {
+	changed := map[string]interface{}{}
+	for k, v := range wanted {
+		if existing, ok := current[k]; !ok || !reflect.DeepEqual(existing, v) {
+			changed[k] = v
+		}
+	}
+	return changed
+}
+
+// planApplyReport summarizes an apply run for human-readable output.
+type planApplyReport struct {
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Skipped   int      `json:"skipped,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// dispatchPlanApplyChanges sends each change's Create/Update/Delete call
+// across a worker pool bounded by parallelism, collecting results into a
+// planApplyReport. It continues past individual failures rather than
+// aborting the batch, mirroring tpclient's Bulk* partial-failure behaviour,
+// and reuses the same tpclient.Run worker pool those helpers are built on.
+func dispatchPlanApplyChanges(client *tpclient.Client, changes []planApplyChange, parallelism int) planApplyReport {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var mu sync.Mutex
+	report := planApplyReport{}
+
+	tpclient.Run(len(changes), parallelism, func(i int) {
+		c := changes[i]
+
+		var opts []tpclient.Option
+		if c.IdempotencyKey != "" {
+			opts = append(opts, tpclient.WithIdempotencyKey(c.IdempotencyKey))
+		}
+
+		var dispatchErr error
+		switch c.Op {
+		case "create":
+			data, merr := json.Marshal(c.Data)
+			if merr != nil {
+				dispatchErr = merr
+				break
+			}
+			_, dispatchErr = client.Create(c.Type, data, opts...)
+		case "update":
+			data, merr := json.Marshal(c.Data)
+			if merr != nil {
+				dispatchErr = merr
+				break
+			}
+			_, dispatchErr = client.Update(c.Type, c.ID, data, opts...)
+		case "delete":
+			dispatchErr = client.Delete(c.Type, c.ID, opts...)
+		default:
+			dispatchErr = fmt.Errorf("unknown op %q", c.Op)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if dispatchErr != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("%s %s/%s: %v", c.Op, c.Type, c.ID, dispatchErr))
+			return
+		}
+		report.Succeeded++
+	})
+
+	return report
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a manifest of TeamPIObjective/Feature records in bulk",
+	Long: `Apply a manifest of entities to TargetProcess in bulk.
+
+The manifest (JSON or YAML) is a list of records, each with a "type"
+(entity type), an optional "id" (present to update, absent to create), an
+optional "op" (create/update/delete, inferred from "id" when absent) and a
+"data" object with the fields to set. Records are sent concurrently,
+bounded by --parallelism; a rejected record does not abort the rest of the
+manifest.
+
+--dry-run prints the planned requests without sending them. --diff fetches
+each update's current state first and only sends the fields that actually
+changed, skipping the request entirely when nothing did.
+
+Example manifest.yaml:
+  - type: TeamPIObjective
+    data: {name: "API Perf", team_id: 1935991, release_id: 1942235, effort: 34}
+  - type: TeamPIObjective
+    id: "12345"
+    data: {effort: 40}
+  - type: TeamPIObjective
+    id: "98765"
+    op: delete
+
+Example:
+  tpcli plan apply --file manifest.yaml --parallelism 8
+  tpcli plan apply --file manifest.yaml --diff --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			return fmt.Errorf("--file flag is required")
+		}
+		parallelism, _ := cmd.Flags().GetInt("parallelism")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		diff, _ := cmd.Flags().GetBool("diff")
+
+		manifestData, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("reading manifest: %w", err)
+		}
+
+		items, err := parsePlanApplyManifest(manifestData)
+		if err != nil {
+			return err
+		}
+
+		// Get configuration - use flags first, then viper fallback
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = viper.GetString("token")
+		}
+
+		baseURL, _ := cmd.Flags().GetString("url")
+		if baseURL == "" {
+			baseURL = viper.GetString("url")
+		}
+
+		verboseFlag, _ := cmd.Flags().GetBool("verbose")
+		verbose := verboseFlag || viper.GetBool("verbose")
+
+		if token == "" {
+			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
+		}
+		if baseURL == "" {
+			return fmt.Errorf("base URL is required (use --url, TP_URL env var, or config file)")
+		}
+
+		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
+
+		changes, skipped, err := resolvePlanApplyChanges(client, items, diff)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			output, err := json.MarshalIndent(changes, "", "  ")
+			if err != nil {
+				return fmt.Errorf("formatting planned changes: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		report := dispatchPlanApplyChanges(client, changes, parallelism)
+		report.Skipped = skipped
+
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("formatting output: %w", err)
+		}
+
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <entity-type> <id>",
+	Short: "Delete an entity in TargetProcess",
+	Long: `Delete an entity in TargetProcess by ID.
+
+Examples:
+  tpcli plan delete TeamPIObjective 12345
+  tpcli plan delete Feature 5678`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entityType := args[0]
+		id := args[1]
+
+		// Get configuration - use flags first, then viper fallback
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = viper.GetString("token")
+		}
+
+		baseURL, _ := cmd.Flags().GetString("url")
+		if baseURL == "" {
+			baseURL = viper.GetString("url")
+		}
+
+		verboseFlag, _ := cmd.Flags().GetBool("verbose")
+		verbose := verboseFlag || viper.GetBool("verbose")
+
+		if token == "" {
+			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
+		}
+		if baseURL == "" {
+			return fmt.Errorf("base URL is required (use --url, TP_URL env var, or config file)")
+		}
+
+		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
+
+		if err := client.Delete(entityType, id); err != nil {
+			return fmt.Errorf("deleting %s %s: %w", entityType, id, err)
+		}
+
+		fmt.Printf("Deleted %s %s\n", entityType, id)
+		return nil
+	},
+}
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize plan tracking for a team and release",
@@ -185,13 +514,35 @@ Examples:
 			return fmt.Errorf("base URL is required (use --url, TP_URL env var, or config file)")
 		}
 
-		// Initialize plan tracking
-		// Creates tracking and feature branches
 		trackingBranch := generateTrackingBranchName(release, team)
 		featureBranch := generateFeatureBranchName(release)
 
+		if gitBranchExists(trackingBranch) {
+			fmt.Printf("Tracking branch already exists: %s\n", trackingBranch)
+		} else {
+			if err := gitCreateOrphanBranch(trackingBranch); err != nil {
+				return fmt.Errorf("creating tracking branch: %w", err)
+			}
+			if _, err := gitCmd("checkout", trackingBranch); err != nil {
+				return fmt.Errorf("checking out tracking branch: %w", err)
+			}
+			if _, err := gitCmd("commit", "--allow-empty", "-m", fmt.Sprintf("plan init: %s %s", release, team)); err != nil {
+				return fmt.Errorf("creating initial tracking commit: %w", err)
+			}
+			fmt.Printf("Created tracking branch: %s\n", trackingBranch)
+		}
+
+		if gitBranchExists(featureBranch) {
+			if _, err := gitCmd("checkout", featureBranch); err != nil {
+				return fmt.Errorf("checking out feature branch: %w", err)
+			}
+		} else {
+			if _, err := gitCmd("checkout", "-b", featureBranch, trackingBranch); err != nil {
+				return fmt.Errorf("creating feature branch: %w", err)
+			}
+		}
+
 		fmt.Printf("Initialized plan tracking for %s %s\n", release, team)
-		fmt.Printf("Created tracking branch: %s\n", trackingBranch)
 		fmt.Printf("Checked out feature branch: %s\n", featureBranch)
 
 		return nil
@@ -202,13 +553,27 @@ var pullCmd = &cobra.Command{
 	Use:   "pull",
 	Short: "Pull latest changes from TargetProcess",
 	Long: `Pull latest changes from TargetProcess and rebase feature branch.
-Fetches the latest plan state from TargetProcess and updates the tracking branch.
-Rebases the current feature branch onto the updated tracking branch.
+
+Fetches TeamPIObjectives and Features for --release/--team, writes each as
+plan/<release>/<team>/<entity-type>/<id>.json, and commits them onto the
+tracking branch (TP-<release>-<team>). The feature branch
+(feature/plan-<release>) is then rebased onto the updated tracking branch,
+or created from it if this is the first pull.
 
 Example:
-  tpcli plan pull`,
+  tpcli plan pull --release PI-4/25 --team "Platform Eco"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get configuration
+		release, _ := cmd.Flags().GetString("release")
+		team, _ := cmd.Flags().GetString("team")
+
+		if release == "" {
+			return fmt.Errorf("--release flag is required")
+		}
+		if team == "" {
+			return fmt.Errorf("--team flag is required")
+		}
+
+		// Get configuration - use flags first, then viper fallback
 		token, _ := cmd.Flags().GetString("token")
 		if token == "" {
 			token = viper.GetString("token")
@@ -219,6 +584,9 @@ Example:
 			baseURL = viper.GetString("url")
 		}
 
+		verboseFlag, _ := cmd.Flags().GetBool("verbose")
+		verbose := verboseFlag || viper.GetBool("verbose")
+
 		if token == "" {
 			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
 		}
@@ -226,9 +594,74 @@ Example:
 			return fmt.Errorf("base URL is required (use --url, TP_URL env var, or config file)")
 		}
 
-		// Pull latest from TargetProcess
-		fmt.Println("Successfully pulled latest changes from TargetProcess")
-		fmt.Println("Feature branch rebased onto tracking branch")
+		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
+
+		trackingBranch := generateTrackingBranchName(release, team)
+		featureBranch := generateFeatureBranchName(release)
+		dir := planDir(release, team)
+		where := fmt.Sprintf("Release.Name eq '%s' and Team.Name eq '%s'", release, team)
+
+		var entities []plansync.Entity
+		for _, entityType := range managedEntityTypes {
+			items, err := client.List(entityType, where, nil, 0, 0)
+			if err != nil {
+				return fmt.Errorf("listing %s: %w", entityType, err)
+			}
+			for _, item := range items {
+				entities = append(entities, plansync.Entity{Type: entityType, ID: entityID(item), Fields: item})
+			}
+		}
+
+		if !gitBranchExists(trackingBranch) {
+			if err := gitCreateOrphanBranch(trackingBranch); err != nil {
+				return fmt.Errorf("creating tracking branch: %w", err)
+			}
+		}
+		if _, err := gitCmd("checkout", trackingBranch); err != nil {
+			return fmt.Errorf("checking out tracking branch: %w", err)
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("clearing stale plan files: %w", err)
+		}
+		for _, e := range entities {
+			if err := plansync.WriteEntityFile(dir, e); err != nil {
+				return fmt.Errorf("writing %s/%s: %w", e.Type, e.ID, err)
+			}
+		}
+
+		if _, err := gitCmd("add", "-A", dir); err != nil {
+			return fmt.Errorf("staging plan files: %w", err)
+		}
+		if changed, err := gitHasChanges(); err != nil {
+			return fmt.Errorf("checking for plan changes: %w", err)
+		} else if changed {
+			msg := fmt.Sprintf("plan pull: %s %s (%d records)", release, team, len(entities))
+			if _, err := gitCmd("commit", "-m", msg); err != nil {
+				return fmt.Errorf("committing pulled plan: %w", err)
+			}
+		}
+
+		if gitBranchExists(featureBranch) {
+			if _, err := gitCmd("checkout", featureBranch); err != nil {
+				return fmt.Errorf("checking out feature branch: %w", err)
+			}
+			if _, err := gitCmd("rebase", trackingBranch); err != nil {
+				return fmt.Errorf("rebasing %s onto %s: %w", featureBranch, trackingBranch, err)
+			}
+		} else if _, err := gitCmd("checkout", "-b", featureBranch, trackingBranch); err != nil {
+			return fmt.Errorf("creating feature branch: %w", err)
+		}
+
+		fmt.Printf("Pulled %d record(s) for %s %s\n", len(entities), release, team)
+		fmt.Printf("Updated tracking branch: %s\n", trackingBranch)
+		fmt.Printf("Feature branch rebased onto tracking branch: %s\n", featureBranch)
 
 		return nil
 	},
@@ -238,13 +671,29 @@ var pushCmd = &cobra.Command{
 	Use:   "push",
 	Short: "Push changes to TargetProcess",
 	Long: `Push changes to TargetProcess.
-Detects changes in the current feature branch, parses them,
-and makes appropriate API calls to TargetProcess.
+
+Compares the plan files on the feature branch against the tracking branch
+(the state as of the last pull) and the tracking branch's current tip
+(the latest known server state), merges field-level edits so concurrent
+changes to different fields don't conflict, and dispatches the result as
+Create/Update/Delete calls. If any record has an unresolved conflict (e.g.
+the same field changed on both sides since the last pull), the conflicts
+are reported and nothing is pushed.
 
 Example:
-  tpcli plan push`,
+  tpcli plan push --release PI-4/25 --team "Platform Eco"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get configuration
+		release, _ := cmd.Flags().GetString("release")
+		team, _ := cmd.Flags().GetString("team")
+
+		if release == "" {
+			return fmt.Errorf("--release flag is required")
+		}
+		if team == "" {
+			return fmt.Errorf("--team flag is required")
+		}
+
+		// Get configuration - use flags first, then viper fallback
 		token, _ := cmd.Flags().GetString("token")
 		if token == "" {
 			token = viper.GetString("token")
@@ -255,6 +704,9 @@ Example:
 			baseURL = viper.GetString("url")
 		}
 
+		verboseFlag, _ := cmd.Flags().GetBool("verbose")
+		verbose := verboseFlag || viper.GetBool("verbose")
+
 		if token == "" {
 			return fmt.Errorf("API token is required (use --token, TP_TOKEN env var, or config file)")
 		}
@@ -262,37 +714,124 @@ Example:
 			return fmt.Errorf("base URL is required (use --url, TP_URL env var, or config file)")
 		}
 
-		// Push changes to TargetProcess
-		fmt.Println("Successfully pushed changes to TargetProcess")
+		trackingBranch := generateTrackingBranchName(release, team)
+		featureBranch := generateFeatureBranchName(release)
+		dir := planDir(release, team)
+
+		if !gitBranchExists(trackingBranch) {
+			return fmt.Errorf("tracking branch %s does not exist; run 'tpcli plan pull' first", trackingBranch)
+		}
+
+		mergeBaseOut, err := gitCmd("merge-base", trackingBranch, featureBranch)
+		if err != nil {
+			return fmt.Errorf("finding merge base of %s and %s: %w", featureBranch, trackingBranch, err)
+		}
+		mergeBase := strings.TrimSpace(mergeBaseOut)
+
+		base, err := readSnapshotAtRef(mergeBase, dir, managedEntityTypes)
+		if err != nil {
+			return fmt.Errorf("reading plan state at the last pull: %w", err)
+		}
+
+		theirs, err := readSnapshotAtRef(trackingBranch, dir, managedEntityTypes)
+		if err != nil {
+			return fmt.Errorf("reading tracking branch plan state: %w", err)
+		}
+
+		ours, err := plansync.ReadSnapshot(dir, managedEntityTypes)
+		if err != nil {
+			return fmt.Errorf("reading local plan state: %w", err)
+		}
+
+		changes, conflicts := plansync.Reconcile(base, ours, theirs)
+
+		if len(conflicts) > 0 {
+			for _, c := range conflicts {
+				fmt.Printf("conflict: %s/%s: %s\n", c.EntityType, c.ID, conflictDescription(c))
+			}
+			return fmt.Errorf("%d unresolved conflict(s); resolve locally and re-run 'tpcli plan push'", len(conflicts))
+		}
+
+		if len(changes) == 0 {
+			fmt.Println("Nothing to push; feature branch matches tracking branch")
+			return nil
+		}
+
+		client := tpclient.NewClient(baseURL, token, verbose)
+		if err := configureClientLogging(client); err != nil {
+			return err
+		}
+		if err := configureAuthenticator(client); err != nil {
+			return err
+		}
+
+		var succeeded, failed int
+		for _, c := range changes {
+			var pushErr error
+			switch c.Op {
+			case plansync.OpAdd:
+				data, merr := json.Marshal(c.Fields)
+				if merr != nil {
+					pushErr = merr
+					break
+				}
+				_, pushErr = client.Create(c.EntityType, data)
+			case plansync.OpModify:
+				data, merr := json.Marshal(c.Fields)
+				if merr != nil {
+					pushErr = merr
+					break
+				}
+				_, pushErr = client.Update(c.EntityType, c.ID, data)
+			case plansync.OpDelete:
+				pushErr = client.Delete(c.EntityType, c.ID)
+			}
+
+			if pushErr != nil {
+				failed++
+				fmt.Printf("failed to push %s/%s: %v\n", c.EntityType, c.ID, pushErr)
+				continue
+			}
+			succeeded++
+		}
+
+		fmt.Printf("Pushed %d change(s) to TargetProcess (%d failed)\n", succeeded, failed)
+		if failed > 0 {
+			return fmt.Errorf("%d of %d change(s) failed to push", failed, succeeded+failed)
+		}
 
 		return nil
 	},
 }
 
-// generateTrackingBranchName generates a tracking branch name from release and team
-// Format: TP-<RELEASE>-<team> (e.g., TP-PI-4-25-platform-eco)
-func generateTrackingBranchName(release string, team string) string {
-	// Normalize release: uppercase, replace / with -, remove special chars
-	releaseNorm := strings.ToUpper(strings.ReplaceAll(release, "/", "-"))
-	// Remove any remaining special characters except dash
-	releaseNorm = strings.Map(func(r rune) rune {
+// normalizeRelease uppercases a release name, replaces / with -, and drops
+// any remaining special characters, e.g. "PI-4/25" -> "PI-4-25".
+func normalizeRelease(release string) string {
+	norm := strings.ToUpper(strings.ReplaceAll(release, "/", "-"))
+	return strings.Map(func(r rune) rune {
 		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
 			return r
 		}
 		return -1
-	}, releaseNorm)
+	}, norm)
+}
 
-	// Normalize team: lowercase, replace spaces with -, remove special chars
-	teamNorm := strings.ToLower(strings.ReplaceAll(team, " ", "-"))
-	// Remove any remaining special characters except dash
-	teamNorm = strings.Map(func(r rune) rune {
+// normalizeTeam lowercases a team name, replaces spaces with -, and drops
+// any remaining special characters, e.g. "Platform Eco" -> "platform-eco".
+func normalizeTeam(team string) string {
+	norm := strings.ToLower(strings.ReplaceAll(team, " ", "-"))
+	return strings.Map(func(r rune) rune {
 		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
 			return r
 		}
 		return -1
-	}, teamNorm)
+	}, norm)
+}
 
-	return fmt.Sprintf("TP-%s-%s", releaseNorm, teamNorm)
+// generateTrackingBranchName generates a tracking branch name from release and team
+// Format: TP-<RELEASE>-<team> (e.g., TP-PI-4-25-platform-eco)
+func generateTrackingBranchName(release string, team string) string {
+	return fmt.Sprintf("TP-%s-%s", normalizeRelease(release), normalizeTeam(team))
 }
 
 // generateFeatureBranchName generates a feature branch name from release
@@ -311,11 +850,23 @@ func init() {
 	planCmd.AddCommand(updateCmd)
 	updateCmd.Flags().String("data", "", "JSON data for entity (required)")
 
+	planCmd.AddCommand(applyCmd)
+	applyCmd.Flags().String("file", "", "Path to a JSON/YAML manifest of records (required)")
+	applyCmd.Flags().Int("parallelism", 1, "Number of concurrent requests")
+	applyCmd.Flags().Bool("dry-run", false, "Print the planned requests without sending them")
+	applyCmd.Flags().Bool("diff", false, "Fetch each update's current state and only send changed fields")
+
+	planCmd.AddCommand(deleteCmd)
+
 	planCmd.AddCommand(initCmd)
 	initCmd.Flags().String("release", "", "Release name (e.g., PI-4/25) (required)")
 	initCmd.Flags().String("team", "", "Team name (required)")
 
 	planCmd.AddCommand(pullCmd)
+	pullCmd.Flags().String("release", "", "Release name (e.g., PI-4/25) (required)")
+	pullCmd.Flags().String("team", "", "Team name (required)")
 
 	planCmd.AddCommand(pushCmd)
+	pushCmd.Flags().String("release", "", "Release name (e.g., PI-4/25) (required)")
+	pushCmd.Flags().String("team", "", "Team name (required)")
 }
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shalomb/tpcli/pkg/plansync"
+)
+
+// managedEntityTypes are the entity types `plan pull`/`plan push` track.
+var managedEntityTypes = []string{"TeamPIObjective", "Feature"}
+
+// planDir returns the directory a release/team's plan files live under,
+// relative to the repository root: plan/<release>/<team>.
+func planDir(release, team string) string {
+	return filepath.Join("plan", normalizeRelease(release), normalizeTeam(team))
+}
+
+// gitCmd runs a git command in the current working directory and returns its
+// combined stdout+stderr, mirroring how ext.go shells out to extensions.
+func gitCmd(args ...string) (string, error) {
+	return gitCmdIn("", args...)
+}
+
+// gitCmdIn runs a git command with dir as its working directory (the
+// current working directory, if dir is empty), so a command can operate on
+// a secondary worktree without disturbing the caller's cwd.
+func gitCmdIn(dir string, args ...string) (string, error) {
+	c := exec.Command("git", args...)
+	c.Dir = dir
+	var out bytes.Buffer
+	c.Stdout = &out
+	c.Stderr = &out
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(out.String()))
+	}
+	return out.String(), nil
+}
+
+// gitBranchExists reports whether branch exists locally.
+func gitBranchExists(branch string) bool {
+	_, err := gitCmd("rev-parse", "--verify", "--quiet", branch)
+	return err == nil
+}
+
+// gitHasChanges reports whether the working tree has staged or unstaged
+// changes relative to HEAD.
+func gitHasChanges() (bool, error) {
+	out, err := gitCmd("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// gitCreateOrphanBranch creates branch with no history and no tracked
+// files, with a single empty commit so it exists as a real ref once this
+// returns. It never touches the caller's working tree: git checkout
+// --orphan followed by git clean -fdx would otherwise wipe every untracked
+// and gitignored file across the whole repo (node_modules, .env, build
+// artifacts, ...), not just the plan/ subdirectory. Instead, the orphan
+// branch is built inside a throwaway git worktree, which is removed once
+// the branch exists; callers that want to work on the new branch still
+// need to check it out themselves afterward. Also refuses to run at all if
+// the working tree has uncommitted changes, since a command further down
+// the line (e.g. checking the new branch out) could otherwise discard them.
+func gitCreateOrphanBranch(branch string) error {
+	if changed, err := gitHasChanges(); err != nil {
+		return fmt.Errorf("checking working tree state: %w", err)
+	} else if changed {
+		return fmt.Errorf("working tree has uncommitted changes; commit or stash them before creating %s", branch)
+	}
+
+	dir, err := os.MkdirTemp("", "tpcli-plan-orphan-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary worktree directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := gitCmd("worktree", "add", "--no-checkout", "--detach", dir); err != nil {
+		return fmt.Errorf("creating temporary worktree: %w", err)
+	}
+	defer gitCmd("worktree", "remove", "--force", dir)
+
+	if _, err := gitCmdIn(dir, "checkout", "--orphan", branch); err != nil {
+		return fmt.Errorf("checking out orphan branch %s: %w", branch, err)
+	}
+	if _, err := gitCmdIn(dir, "rm", "-rf", "--cached", "."); err != nil {
+		return fmt.Errorf("clearing index for orphan branch %s: %w", branch, err)
+	}
+	if _, err := gitCmdIn(dir, "clean", "-fdx"); err != nil {
+		return fmt.Errorf("clearing working tree for orphan branch %s: %w", branch, err)
+	}
+	if _, err := gitCmdIn(dir, "commit", "--allow-empty", "-m", fmt.Sprintf("tpcli: initialize %s", branch)); err != nil {
+		return fmt.Errorf("committing orphan branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// readSnapshotAtRef reads the managed entity files under dir as they exist
+// at ref (a branch, tag or commit), without checking that ref out. A ref or
+// path that doesn't exist yet is treated as an empty snapshot, since that's
+// the expected state before a plan's first pull.
+func readSnapshotAtRef(ref, dir string, entityTypes []string) (plansync.Snapshot, error) {
+	var entities []plansync.Entity
+
+	for _, entityType := range entityTypes {
+		prefix := filepath.Join(dir, entityType)
+		out, err := gitCmd("ls-tree", "-r", "--name-only", ref, "--", prefix)
+		if err != nil || strings.TrimSpace(out) == "" {
+			continue
+		}
+
+		for _, path := range strings.Split(strings.TrimSpace(out), "\n") {
+			if filepath.Ext(path) != ".json" {
+				continue
+			}
+			id := strings.TrimSuffix(filepath.Base(path), ".json")
+
+			data, err := gitCmd("show", fmt.Sprintf("%s:%s", ref, path))
+			if err != nil {
+				return nil, fmt.Errorf("reading %s at %s: %w", path, ref, err)
+			}
+
+			var fields map[string]interface{}
+			if err := json.Unmarshal([]byte(data), &fields); err != nil {
+				return nil, fmt.Errorf("parsing %s at %s: %w", path, ref, err)
+			}
+
+			entities = append(entities, plansync.Entity{Type: entityType, ID: id, Fields: fields})
+		}
+	}
+
+	return plansync.NewSnapshot(entities), nil
+}
+
+// entityID extracts an entity's ID from a TargetProcess API record,
+// accepting either the API's own "Id" casing or the lowercase "id" used by
+// test fixtures and manifests.
+func entityID(item map[string]interface{}) string {
+	raw, ok := item["Id"]
+	if !ok {
+		raw, ok = item["id"]
+	}
+	if !ok {
+		return ""
+	}
+	switch v := raw.(type) {
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", raw)
+	}
+}
+
+// conflictDescription renders a plansync.Conflict for command-line output.
+func conflictDescription(c plansync.Conflict) string {
+	switch c.Kind {
+	case plansync.ConflictField:
+		return fmt.Sprintf("fields changed on both sides since the last pull: %s", strings.Join(c.Fields, ", "))
+	case plansync.ConflictDeletedRemotely:
+		return "deleted on the server since the last pull, but still edited locally"
+	case plansync.ConflictAddedBothSides:
+		return "added independently both locally and on the server"
+	default:
+		return "unresolved conflict"
+	}
+}
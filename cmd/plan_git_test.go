@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// withTestRepo creates a throwaway git repo with one commit (README.md) in
+// a temp directory, chdirs into it for the duration of fn, and restores the
+// original working directory afterward. gitCmd/gitCreateOrphanBranch always
+// run in the current working directory, so this is the only way to
+// exercise them without touching the real tpcli checkout.
+func withTestRepo(t *testing.T, fn func()) {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(cwd); err != nil {
+			t.Fatalf("restoring working directory: %v", err)
+		}
+	}()
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+	} {
+		if _, err := gitCmd(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	if err := os.WriteFile("README.md", []byte("hello\n"), 0644); err != nil {
+		t.Fatalf("writing README.md: %v", err)
+	}
+	if _, err := gitCmd("add", "README.md"); err != nil {
+		t.Fatalf("git add: %v", err)
+	}
+	if _, err := gitCmd("commit", "-m", "init"); err != nil {
+		t.Fatalf("git commit: %v", err)
+	}
+
+	fn()
+}
+
+// TestGitCreateOrphanBranchRefusesWithUncommittedChanges checks that
+// gitCreateOrphanBranch bails out before creating anything if the working
+// tree isn't clean, instead of risking a checkout that could discard
+// uncommitted work.
+func TestGitCreateOrphanBranchRefusesWithUncommittedChanges(t *testing.T) {
+	withTestRepo(t, func() {
+		if err := os.WriteFile("README.md", []byte("dirty\n"), 0644); err != nil {
+			t.Fatalf("writing README.md: %v", err)
+		}
+
+		if err := gitCreateOrphanBranch("tracking"); err == nil {
+			t.Fatal("expected an error for an unclean working tree, got none")
+		}
+
+		if gitBranchExists("tracking") {
+			t.Error("tracking branch should not have been created")
+		}
+	})
+}
+
+// TestGitCreateOrphanBranchLeavesWorkingTreeUntouched checks that
+// gitCreateOrphanBranch creates the branch without switching the caller's
+// checked-out branch or disturbing untracked/gitignored files - the bug
+// the worktree-isolation rewrite fixed.
+func TestGitCreateOrphanBranchLeavesWorkingTreeUntouched(t *testing.T) {
+	withTestRepo(t, func() {
+		startBranchOut, err := gitCmd("rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			t.Fatalf("rev-parse HEAD: %v", err)
+		}
+		startBranch := strings.TrimSpace(startBranchOut)
+
+		if err := os.WriteFile(".gitignore", []byte(".env\n"), 0644); err != nil {
+			t.Fatalf("writing .gitignore: %v", err)
+		}
+		if _, err := gitCmd("add", ".gitignore"); err != nil {
+			t.Fatalf("git add: %v", err)
+		}
+		if _, err := gitCmd("commit", "-m", "add gitignore"); err != nil {
+			t.Fatalf("git commit: %v", err)
+		}
+		if err := os.WriteFile(".env", []byte("SECRET\n"), 0644); err != nil {
+			t.Fatalf("writing .env: %v", err)
+		}
+
+		if err := gitCreateOrphanBranch("tracking"); err != nil {
+			t.Fatalf("gitCreateOrphanBranch: %v", err)
+		}
+
+		if !gitBranchExists("tracking") {
+			t.Fatal("expected tracking branch to exist")
+		}
+
+		endBranchOut, err := gitCmd("rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			t.Fatalf("rev-parse HEAD: %v", err)
+		}
+		if endBranch := strings.TrimSpace(endBranchOut); endBranch != startBranch {
+			t.Errorf("expected to stay on %q, ended up on %q", startBranch, endBranch)
+		}
+
+		if _, err := os.Stat("README.md"); err != nil {
+			t.Errorf("README.md should still be present: %v", err)
+		}
+		if _, err := os.Stat(".env"); err != nil {
+			t.Errorf(".env should still be present: %v", err)
+		}
+
+		if changed, err := gitHasChanges(); err != nil {
+			t.Fatalf("gitHasChanges: %v", err)
+		} else if changed {
+			t.Error("working tree should still be clean")
+		}
+	})
+}
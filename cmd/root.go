@@ -1,16 +1,25 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
+	"github.com/shalomb/tpcli/pkg/output"
+	"github.com/shalomb/tpcli/pkg/tpclient"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile string
-	verbose bool
+	cfgFile      string
+	verbose      bool
+	outputFormat string
+	template     string
+	templateFile string
+	logLevel     string
+	logFormat    string
 )
 
 var rootCmd = &cobra.Command{
@@ -26,6 +35,41 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// newFormatter builds the output.Formatter for the current --output,
+// --template and --template-file flags. Commands call this once they have
+// their data ready to render.
+func newFormatter() (output.Formatter, error) {
+	return output.New(outputFormat, output.Options{
+		Template:     template,
+		TemplateFile: templateFile,
+	})
+}
+
+// signalContext returns a context cancelled on SIGINT, so a long-running
+// list --all or discover can abort cleanly instead of running to
+// completion. Callers must call the returned stop func once done, per
+// signal.NotifyContext.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// configureClientLogging applies the --log-level/--log-format flags to
+// client, overriding the debug/warn default NewClient picked from
+// --verbose. Flags left unset leave that default in place.
+func configureClientLogging(client *tpclient.Client) error {
+	if logLevel != "" {
+		level, err := tpclient.ParseLogLevel(logLevel)
+		if err != nil {
+			return err
+		}
+		client.SetLogLevel(level)
+	}
+	if logFormat != "" {
+		client.SetLogFormat(logFormat)
+	}
+	return nil
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
@@ -34,6 +78,11 @@ func init() {
 	rootCmd.PersistentFlags().String("token", "", "TargetProcess API token")
 	rootCmd.PersistentFlags().String("url", "", "TargetProcess base URL (e.g., https://company.tpondemand.com)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "output format: json, jsonl, yaml, csv, table, template")
+	rootCmd.PersistentFlags().StringVar(&template, "template", "", "Go text/template body, used with --output template")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template-file", "", "path to a Go text/template file, used with --output template")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "log level: error, warn, info, debug or trace (default warn, or debug with --verbose)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "log output format: text or json (default text)")
 
 	// Bind flags to viper
 	viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup("token"))
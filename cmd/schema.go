@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shalomb/tpcli/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+// loadCachedSchema loads the schema cache 'tpcli discover' produces. It
+// returns nil, without error, when no cache exists yet (validation and
+// completion are a nice-to-have, not a hard dependency on discover having
+// been run first).
+func loadCachedSchema() *schema.Schema {
+	path, err := schema.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	sch, err := schema.Load(path)
+	if err != nil {
+		return nil
+	}
+	return sch
+}
+
+// validateFields checks that each of fields is a known field of entityType
+// in sch, returning a "did you mean" error for the first one that isn't. A
+// nil sch (no schema cache yet) or an unknown entityType skips validation.
+func validateFields(sch *schema.Schema, entityType string, fields []string) error {
+	if sch == nil || !sch.HasEntityType(entityType) {
+		return nil
+	}
+	for _, f := range fields {
+		top := f
+		if i := strings.IndexByte(f, '.'); i >= 0 {
+			top = f[:i]
+		}
+		if sch.HasField(entityType, top) {
+			continue
+		}
+		if suggestion, ok := sch.SuggestField(entityType, top); ok {
+			return fmt.Errorf("unknown field %q for %s (did you mean %q?)", f, entityType, suggestion)
+		}
+		return fmt.Errorf("unknown field %q for %s", f, entityType)
+	}
+	return nil
+}
+
+// whereClauseField extracts the leading field path of a single where
+// clause, e.g. "EntityState.Name eq 'Open'" -> "EntityState.Name".
+var whereClauseField = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_.]*)`)
+
+// whereClauseSplit splits a where expression on top-level "and"/"or", so
+// each clause can be checked independently. It doesn't attempt to parse
+// parenthesized sub-expressions; those clauses are skipped rather than
+// misreported.
+var whereClauseSplit = regexp.MustCompile(`(?i)\s+(?:and|or)\s+`)
+
+// validateWhere checks that the leading field of each clause in where is a
+// known field of entityType in sch. A nil sch or an unknown entityType
+// skips validation, as does an empty where.
+func validateWhere(sch *schema.Schema, entityType, where string) error {
+	if sch == nil || !sch.HasEntityType(entityType) || where == "" {
+		return nil
+	}
+	for _, clause := range whereClauseSplit.Split(where, -1) {
+		if strings.ContainsAny(clause, "()") {
+			continue
+		}
+		m := whereClauseField.FindStringSubmatch(clause)
+		if m == nil {
+			continue
+		}
+		top := m[1]
+		if i := strings.IndexByte(top, '.'); i >= 0 {
+			top = top[:i]
+		}
+		if sch.HasField(entityType, top) {
+			continue
+		}
+		if suggestion, ok := sch.SuggestField(entityType, top); ok {
+			return fmt.Errorf("unknown field %q in --where for %s (did you mean %q?)", top, entityType, suggestion)
+		}
+		return fmt.Errorf("unknown field %q in --where for %s", top, entityType)
+	}
+	return nil
+}
+
+// completeEntityTypes is a cobra ValidArgsFunction offering entity type
+// names from the schema cache, for commands whose first positional
+// argument is an entity type.
+func completeEntityTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	sch := loadCachedSchema()
+	if sch == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return sch.EntityTypeNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFields is a flag completion function offering field names for
+// the entity type named in args[0], from the schema cache.
+func completeFields(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	sch := loadCachedSchema()
+	if sch == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return sch.FieldNames(args[0]), cobra.ShellCompDirectiveNoFileComp
+}
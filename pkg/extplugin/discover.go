@@ -0,0 +1,82 @@
+package extplugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExecPrefix is the Git-style naming convention a discoverable extension
+// executable can use instead of shipping a manifest: tpcli-ext-<name>.
+const ExecPrefix = "tpcli-ext-"
+
+// Discover scans dirs, in order, for tpcli extensions. An extension is
+// either an executable named tpcli-ext-<name>, or any executable with a
+// sibling <name>.tpcli-plugin.yaml manifest in the same directory. The
+// first match for a given name wins, mirroring PATH lookup semantics, so
+// dirs should be passed most-specific first.
+func Discover(dirs []string) ([]Plugin, error) {
+	seen := map[string]bool{}
+	var plugins []Plugin
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue // unreadable/missing dirs are simply not sources of plugins
+		}
+
+		names := map[string]bool{}
+		for _, e := range entries {
+			names[e.Name()] = true
+		}
+
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+
+			if strings.HasSuffix(e.Name(), ManifestSuffix) {
+				base := strings.TrimSuffix(e.Name(), ManifestSuffix)
+				if !names[base] || !isExecutable(filepath.Join(dir, base)) {
+					continue // manifest with no executable alongside it
+				}
+				if seen[base] {
+					continue
+				}
+
+				p, err := loadManifest(filepath.Join(dir, e.Name()))
+				if err != nil {
+					return nil, err
+				}
+				if p.Name == "" {
+					p.Name = base
+				}
+				p.Path = filepath.Join(dir, base)
+				plugins = append(plugins, p)
+				seen[base] = true
+				continue
+			}
+
+			if strings.HasPrefix(e.Name(), ExecPrefix) {
+				name := strings.TrimPrefix(e.Name(), ExecPrefix)
+				if seen[name] {
+					continue
+				}
+				if isExecutable(filepath.Join(dir, e.Name())) {
+					plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, e.Name())})
+					seen[name] = true
+				}
+			}
+		}
+	}
+
+	return plugins, nil
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return info.Mode()&0111 != 0
+}
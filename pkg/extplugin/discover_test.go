@@ -0,0 +1,94 @@
+package extplugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeExecutable(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho ok\n"), 0o755); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
+
+func TestDiscoverFindsExecPrefixedExtension(t *testing.T) {
+	dir := t.TempDir()
+	writeExecutable(t, filepath.Join(dir, "tpcli-ext-art-dashboard"))
+
+	plugins, err := Discover([]string{dir})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(plugins) != 1 || plugins[0].Name != "art-dashboard" {
+		t.Fatalf("expected one plugin named art-dashboard, got %v", plugins)
+	}
+}
+
+func TestDiscoverFindsManifestDescribedExtension(t *testing.T) {
+	dir := t.TempDir()
+	writeExecutable(t, filepath.Join(dir, "team-deep-dive"))
+	manifest := `
+name: team-deep-dive
+version: "1.2.0"
+description: Per-team PI drill-down
+category: Team
+requires_token: true
+`
+	if err := os.WriteFile(filepath.Join(dir, "team-deep-dive.tpcli-plugin.yaml"), []byte(manifest), 0o644); err != nil {
+		t.Fatalf("writing manifest: %v", err)
+	}
+
+	plugins, err := Discover([]string{dir})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(plugins) != 1 {
+		t.Fatalf("expected one plugin, got %v", plugins)
+	}
+	p := plugins[0]
+	if p.Name != "team-deep-dive" || p.Category != "Team" || !p.RequiresToken {
+		t.Errorf("unexpected plugin: %+v", p)
+	}
+}
+
+func TestDiscoverIgnoresOrphanManifest(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ghost.tpcli-plugin.yaml"), []byte("name: ghost\n"), 0o644); err != nil {
+		t.Fatalf("writing manifest: %v", err)
+	}
+
+	plugins, err := Discover([]string{dir})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(plugins) != 0 {
+		t.Errorf("expected no plugins for a manifest with no executable, got %v", plugins)
+	}
+}
+
+func TestDiscoverFirstDirWins(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+	writeExecutable(t, filepath.Join(first, "tpcli-ext-art-dashboard"))
+	writeExecutable(t, filepath.Join(second, "tpcli-ext-art-dashboard"))
+
+	plugins, err := Discover([]string{first, second})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(plugins) != 1 || plugins[0].Path != filepath.Join(first, "tpcli-ext-art-dashboard") {
+		t.Fatalf("expected the first dir's copy to win, got %v", plugins)
+	}
+}
+
+func TestDiscoverSkipsMissingDirs(t *testing.T) {
+	plugins, err := Discover([]string{filepath.Join(t.TempDir(), "does-not-exist")})
+	if err != nil {
+		t.Fatalf("expected missing dirs to be skipped without error, got %v", err)
+	}
+	if len(plugins) != 0 {
+		t.Errorf("expected no plugins, got %v", plugins)
+	}
+}
@@ -0,0 +1,56 @@
+package extplugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Event is a structured log line an extension can emit on its
+// TPCLI_JSON_FDS pipe instead of printing free-form text, letting tpcli
+// render it consistently alongside the extension's own stdout/stderr.
+type Event struct {
+	Level  string                 `json:"level"`
+	Msg    string                 `json:"msg"`
+	Entity map[string]interface{} `json:"entity,omitempty"`
+}
+
+// StreamEvents reads newline-delimited Event JSON from r until EOF and
+// renders each one to w. Malformed lines are rendered verbatim rather than
+// aborting the stream, since a misbehaving extension shouldn't be able to
+// hide its other, well-formed events.
+func StreamEvents(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var e Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			fmt.Fprintf(w, "%s\n", line)
+			continue
+		}
+
+		renderEvent(w, e)
+	}
+
+	return scanner.Err()
+}
+
+func renderEvent(w io.Writer, e Event) {
+	if len(e.Entity) > 0 {
+		entity, err := json.Marshal(e.Entity)
+		if err != nil {
+			fmt.Fprintf(w, "[%s] %s\n", e.Level, e.Msg)
+			return
+		}
+		fmt.Fprintf(w, "[%s] %s %s\n", e.Level, e.Msg, entity)
+		return
+	}
+	fmt.Fprintf(w, "[%s] %s\n", e.Level, e.Msg)
+}
@@ -0,0 +1,43 @@
+package extplugin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStreamEventsRendersLevelAndMessage(t *testing.T) {
+	in := strings.NewReader(`{"level":"info","msg":"syncing features"}` + "\n")
+	var out bytes.Buffer
+
+	if err := StreamEvents(in, &out); err != nil {
+		t.Fatalf("StreamEvents: %v", err)
+	}
+	if got := out.String(); got != "[info] syncing features\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+}
+
+func TestStreamEventsRendersEntity(t *testing.T) {
+	in := strings.NewReader(`{"level":"warn","msg":"stale objective","entity":{"id":"42"}}` + "\n")
+	var out bytes.Buffer
+
+	if err := StreamEvents(in, &out); err != nil {
+		t.Fatalf("StreamEvents: %v", err)
+	}
+	if got := out.String(); got != `[warn] stale objective {"id":"42"}`+"\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+}
+
+func TestStreamEventsPassesThroughMalformedLines(t *testing.T) {
+	in := strings.NewReader("not json\n")
+	var out bytes.Buffer
+
+	if err := StreamEvents(in, &out); err != nil {
+		t.Fatalf("StreamEvents: %v", err)
+	}
+	if got := out.String(); got != "not json\n" {
+		t.Errorf("expected malformed line passed through verbatim, got %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package extplugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Install downloads the extension at url into destDir, verifying its
+// contents against the expected sha256 checksum (hex-encoded) before
+// making it executable. It returns the path the extension was installed
+// to. The download is written to a temp file first so a checksum mismatch
+// or interrupted transfer never leaves a partial extension in destDir.
+func Install(url, checksum, destDir string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: unexpected status %s", url, resp.Status)
+	}
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", destDir, err)
+	}
+
+	tmp, err := os.CreateTemp(destDir, ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		return "", fmt.Errorf("downloading %s: %w", url, err)
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); got != checksum {
+		return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", url, checksum, got)
+	}
+
+	name := filepath.Base(url)
+	dest := filepath.Join(destDir, name)
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("finalizing download: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", fmt.Errorf("installing to %s: %w", dest, err)
+	}
+	if err := os.Chmod(dest, 0o755); err != nil {
+		return "", fmt.Errorf("making %s executable: %w", dest, err)
+	}
+
+	return dest, nil
+}
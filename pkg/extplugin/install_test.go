@@ -0,0 +1,96 @@
+package extplugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstallDownloadsAndVerifiesChecksum(t *testing.T) {
+	payload := []byte("#!/bin/sh\necho fake-plugin\n")
+	sum := sha256.Sum256(payload)
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	destDir := t.TempDir()
+	dest, err := Install(server.URL+"/tpcli-ext-fake-plugin", checksum, destDir)
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading installed file: %v", err)
+	}
+	if string(got) != string(payload) {
+		t.Errorf("installed file contents mismatch")
+	}
+
+	info, err := os.Stat(dest)
+	if err != nil {
+		t.Fatalf("stat installed file: %v", err)
+	}
+	if info.Mode()&0111 == 0 {
+		t.Errorf("expected installed file to be executable, got mode %v", info.Mode())
+	}
+}
+
+func TestInstallRejectsChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("unexpected contents"))
+	}))
+	defer server.Close()
+
+	destDir := t.TempDir()
+	_, err := Install(server.URL+"/tpcli-ext-fake-plugin", "0000000000000000000000000000000000000000000000000000000000000000", destDir)
+	if err == nil {
+		t.Fatal("expected an error for a checksum mismatch")
+	}
+
+	entries, readErr := os.ReadDir(destDir)
+	if readErr != nil {
+		t.Fatalf("reading destDir: %v", readErr)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no files left behind after a checksum mismatch, got %v", entries)
+	}
+}
+
+func TestInstallPropagatesHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := Install(server.URL+"/missing", "deadbeef", t.TempDir())
+	if err == nil {
+		t.Fatal("expected an error for a 404 response")
+	}
+}
+
+func TestInstallDestDirIsCreatedIfMissing(t *testing.T) {
+	payload := []byte("payload")
+	sum := sha256.Sum256(payload)
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	destDir := filepath.Join(t.TempDir(), "nested", "plugins")
+	if _, err := Install(server.URL+"/tpcli-ext-x", checksum, destDir); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if _, err := os.Stat(destDir); err != nil {
+		t.Errorf("expected destDir to be created, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package extplugin discovers, describes and runs tpcli extensions:
+// external executables that implement ART, PI or team-level tooling on top
+// of the tpcli API client.
+package extplugin
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Plugin describes a discovered extension, merging what was found on disk
+// (name, path) with whatever a sibling manifest declared about it.
+type Plugin struct {
+	Name          string `yaml:"name"`
+	Path          string `yaml:"-"`
+	Version       string `yaml:"version"`
+	Description   string `yaml:"description"`
+	Category      string `yaml:"category"`
+	RequiresToken bool   `yaml:"requires_token"`
+	SchemaURL     string `yaml:"schema_url"`
+}
+
+// ManifestSuffix is the filename suffix a plugin manifest must use,
+// sitting alongside the executable it describes (e.g. team-deep-dive and
+// team-deep-dive.tpcli-plugin.yaml).
+const ManifestSuffix = ".tpcli-plugin.yaml"
+
+// loadManifest parses a plugin manifest file.
+func loadManifest(path string) (Plugin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Plugin{}, fmt.Errorf("reading manifest %s: %w", path, err)
+	}
+
+	var p Plugin
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return Plugin{}, fmt.Errorf("parsing manifest %s: %w", path, err)
+	}
+	return p, nil
+}
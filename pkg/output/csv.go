@@ -0,0 +1,38 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// csvFormatter renders data as CSV, with a header row followed by one row
+// per entity. Column order follows fields when given, otherwise the first
+// entity's keys, sorted.
+type csvFormatter struct{}
+
+func (csvFormatter) Format(w io.Writer, data interface{}, fields []string) error {
+	rs, err := rows(data)
+	if err != nil {
+		return err
+	}
+
+	cols := columns(fields, rs)
+	out := csv.NewWriter(w)
+
+	if err := out.Write(cols); err != nil {
+		return fmt.Errorf("writing csv header: %w", err)
+	}
+	for _, r := range rs {
+		record := make([]string, len(cols))
+		for i, c := range cols {
+			record[i] = cell(r[c])
+		}
+		if err := out.Write(record); err != nil {
+			return fmt.Errorf("writing csv row: %w", err)
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSVFormatterHonoursFieldOrder(t *testing.T) {
+	var out bytes.Buffer
+	data := []map[string]interface{}{
+		{"Id": float64(1), "Name": "Feature 1"},
+	}
+
+	if err := (csvFormatter{}).Format(&out, data, []string{"Name", "Id"}); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if lines[0] != "Name,Id" {
+		t.Errorf("expected header Name,Id, got %q", lines[0])
+	}
+	if lines[1] != "Feature 1,1" {
+		t.Errorf("expected row Feature 1,1, got %q", lines[1])
+	}
+}
+
+func TestCSVFormatterFallsBackToSortedKeys(t *testing.T) {
+	var out bytes.Buffer
+	data := []map[string]interface{}{
+		{"Name": "Feature 1", "Id": float64(1)},
+	}
+
+	if err := (csvFormatter{}).Format(&out, data, nil); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if lines[0] != "Id,Name" {
+		t.Errorf("expected header Id,Name, got %q", lines[0])
+	}
+}
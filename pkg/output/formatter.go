@@ -0,0 +1,106 @@
+// Package output renders TargetProcess API responses (a single entity, or a
+// list of entities) in the format the user asked for on the command line,
+// so tpcli's output is as usable in shell pipelines and spreadsheets as it
+// is as a JSON dump.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Formatter renders entity data to w. data is either a single entity
+// (map[string]interface{}) or a list of entities ([]map[string]interface{});
+// implementations that only make sense for one shape normalize via rows.
+// fields, when non-empty, fixes column order for formats that have columns
+// (csv, table); formats without columns ignore it.
+type Formatter interface {
+	Format(w io.Writer, data interface{}, fields []string) error
+}
+
+// Options carries format-specific settings that can't be expressed as plain
+// data, namely the template body for the "template" format.
+type Options struct {
+	// Template is an inline Go text/template body, used by the "template"
+	// format. TemplateFile is read instead when Template is empty.
+	Template string
+	// TemplateFile is a path to a Go text/template file, used by the
+	// "template" format when Template is empty.
+	TemplateFile string
+}
+
+// New returns the Formatter registered under name. Supported names are
+// "json", "jsonl", "yaml", "csv", "table" and "template".
+func New(name string, opts Options) (Formatter, error) {
+	switch name {
+	case "json", "":
+		return jsonFormatter{}, nil
+	case "jsonl":
+		return jsonlFormatter{}, nil
+	case "yaml":
+		return yamlFormatter{}, nil
+	case "csv":
+		return csvFormatter{}, nil
+	case "table":
+		return tableFormatter{}, nil
+	case "template":
+		return newTemplateFormatter(opts)
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want one of json, jsonl, yaml, csv, table, template)", name)
+	}
+}
+
+// rows normalizes data into a list of entities: a single entity becomes a
+// one-element list, a list passes through unchanged, and anything else is
+// an error since csv/table/jsonl have no other way to render it.
+func rows(data interface{}) ([]map[string]interface{}, error) {
+	switch v := data.(type) {
+	case []map[string]interface{}:
+		return v, nil
+	case map[string]interface{}:
+		return []map[string]interface{}{v}, nil
+	default:
+		return nil, fmt.Errorf("output: cannot render %T as rows", data)
+	}
+}
+
+// columns returns fields if non-empty, otherwise the keys of the first row
+// in alphabetical order, so column order is stable across runs even though
+// map iteration order is not.
+func columns(fields []string, rs []map[string]interface{}) []string {
+	if len(fields) > 0 {
+		return fields
+	}
+	if len(rs) == 0 {
+		return nil
+	}
+	cols := make([]string, 0, len(rs[0]))
+	for k := range rs[0] {
+		cols = append(cols, k)
+	}
+	sort.Strings(cols)
+	return cols
+}
+
+// cell renders a single field value as a string for csv/table, flattening
+// nested JSON values rather than printing Go's default struct/map syntax.
+func cell(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		if t == float64(int64(t)) {
+			return fmt.Sprintf("%d", int64(t))
+		}
+		return fmt.Sprintf("%g", t)
+	default:
+		if b, err := json.Marshal(t); err == nil {
+			return string(b)
+		}
+		return fmt.Sprintf("%v", t)
+	}
+}
@@ -0,0 +1,51 @@
+package output
+
+import "testing"
+
+func TestNewReturnsErrorForUnknownFormat(t *testing.T) {
+	if _, err := New("xml", Options{}); err == nil {
+		t.Fatal("expected error for unknown format, got none")
+	}
+}
+
+func TestNewDefaultsToJSON(t *testing.T) {
+	f, err := New("", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, ok := f.(jsonFormatter); !ok {
+		t.Errorf("expected jsonFormatter, got %T", f)
+	}
+}
+
+func TestColumnsPrefersExplicitFields(t *testing.T) {
+	rs := []map[string]interface{}{{"Id": 1, "Name": "a"}}
+	got := columns([]string{"Name", "Id"}, rs)
+	if len(got) != 2 || got[0] != "Name" || got[1] != "Id" {
+		t.Errorf("expected explicit field order preserved, got %v", got)
+	}
+}
+
+func TestColumnsFallsBackToSortedKeys(t *testing.T) {
+	rs := []map[string]interface{}{{"Name": "a", "Id": 1}}
+	got := columns(nil, rs)
+	if len(got) != 2 || got[0] != "Id" || got[1] != "Name" {
+		t.Errorf("expected sorted keys [Id Name], got %v", got)
+	}
+}
+
+func TestRowsNormalizesSingleEntity(t *testing.T) {
+	rs, err := rows(map[string]interface{}{"Id": 1})
+	if err != nil {
+		t.Fatalf("rows: %v", err)
+	}
+	if len(rs) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rs))
+	}
+}
+
+func TestRowsRejectsUnsupportedType(t *testing.T) {
+	if _, err := rows("not a map"); err == nil {
+		t.Fatal("expected error for unsupported type, got none")
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonFormatter renders data as a single, indented JSON document. It is the
+// default format, matching tpcli's historical json.MarshalIndent output.
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(w io.Writer, data interface{}, fields []string) error {
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("formatting json: %w", err)
+	}
+	fmt.Fprintln(w, string(out))
+	return nil
+}
+
+// jsonlFormatter renders data as newline-delimited JSON, one compact object
+// per line. A single entity is rendered as one line; a list is one line per
+// entity, for streaming into tools like jq that expect JSON Lines.
+type jsonlFormatter struct{}
+
+func (jsonlFormatter) Format(w io.Writer, data interface{}, fields []string) error {
+	enc := json.NewEncoder(w)
+
+	if m, ok := data.(map[string]interface{}); ok {
+		if err := enc.Encode(m); err != nil {
+			return fmt.Errorf("formatting jsonl: %w", err)
+		}
+		return nil
+	}
+
+	rs, err := rows(data)
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("formatting jsonl: %w", err)
+		}
+	}
+	return nil
+}
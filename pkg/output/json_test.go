@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJSONFormatterIndentsEntity(t *testing.T) {
+	var out bytes.Buffer
+	data := map[string]interface{}{"Id": float64(1), "Name": "Feature 1"}
+
+	if err := (jsonFormatter{}).Format(&out, data, nil); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "\"Id\": 1") {
+		t.Errorf("expected indented JSON, got %q", out.String())
+	}
+}
+
+func TestJSONLFormatterOneLinePerEntity(t *testing.T) {
+	var out bytes.Buffer
+	data := []map[string]interface{}{
+		{"Id": float64(1)},
+		{"Id": float64(2)},
+	}
+
+	if err := (jsonlFormatter{}).Format(&out, data, nil); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), out.String())
+	}
+	if lines[0] != `{"Id":1}` || lines[1] != `{"Id":2}` {
+		t.Errorf("unexpected jsonl output: %v", lines)
+	}
+}
+
+func TestJSONLFormatterSingleEntity(t *testing.T) {
+	var out bytes.Buffer
+	data := map[string]interface{}{"Id": float64(1)}
+
+	if err := (jsonlFormatter{}).Format(&out, data, nil); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	if got := strings.TrimRight(out.String(), "\n"); got != `{"Id":1}` {
+		t.Errorf("unexpected jsonl output: %q", got)
+	}
+}
@@ -0,0 +1,35 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// tableFormatter renders data as a human-readable table with aligned
+// columns, the same column ordering rules as csvFormatter.
+type tableFormatter struct{}
+
+func (tableFormatter) Format(w io.Writer, data interface{}, fields []string) error {
+	rs, err := rows(data)
+	if err != nil {
+		return err
+	}
+
+	cols := columns(fields, rs)
+	if len(cols) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	for _, r := range rs {
+		record := make([]string, len(cols))
+		for i, c := range cols {
+			record[i] = cell(r[c])
+		}
+		fmt.Fprintln(tw, strings.Join(record, "\t"))
+	}
+	return tw.Flush()
+}
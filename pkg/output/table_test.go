@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTableFormatterAlignsColumns(t *testing.T) {
+	var out bytes.Buffer
+	data := []map[string]interface{}{
+		{"Id": float64(1), "Name": "A"},
+		{"Id": float64(22), "Name": "Bug"},
+	}
+
+	if err := (tableFormatter{}).Format(&out, data, []string{"Id", "Name"}); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d lines: %q", len(lines), out.String())
+	}
+	if !strings.HasPrefix(lines[0], "Id") {
+		t.Errorf("expected header to start with Id, got %q", lines[0])
+	}
+}
+
+func TestTableFormatterEmptyRowsProducesNoOutput(t *testing.T) {
+	var out bytes.Buffer
+
+	if err := (tableFormatter{}).Format(&out, []map[string]interface{}{}, nil); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("expected no output for empty rows, got %q", out.String())
+	}
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+)
+
+// templateFormatter renders data through a user-supplied Go text/template,
+// once per entity for a list, so templates can be written against a single
+// entity's fields regardless of whether the caller requested one or many.
+type templateFormatter struct {
+	tmpl *template.Template
+}
+
+// newTemplateFormatter parses opts.Template, or opts.TemplateFile if
+// Template is empty, into a templateFormatter.
+func newTemplateFormatter(opts Options) (Formatter, error) {
+	body := opts.Template
+	if body == "" {
+		if opts.TemplateFile == "" {
+			return nil, fmt.Errorf("output: template format requires --template or --template-file")
+		}
+		data, err := os.ReadFile(opts.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading template file: %w", err)
+		}
+		body = string(data)
+	}
+
+	tmpl, err := template.New("output").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+
+	return templateFormatter{tmpl: tmpl}, nil
+}
+
+func (f templateFormatter) Format(w io.Writer, data interface{}, fields []string) error {
+	if m, ok := data.(map[string]interface{}); ok {
+		return f.tmpl.Execute(w, m)
+	}
+
+	rs, err := rows(data)
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		if err := f.tmpl.Execute(w, r); err != nil {
+			return fmt.Errorf("executing template: %w", err)
+		}
+	}
+	return nil
+}
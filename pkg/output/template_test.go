@@ -0,0 +1,40 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTemplateFormatterRendersEachEntity(t *testing.T) {
+	f, err := newTemplateFormatter(Options{Template: "{{.Id}}: {{.Name}}\n"})
+	if err != nil {
+		t.Fatalf("newTemplateFormatter: %v", err)
+	}
+
+	var out bytes.Buffer
+	data := []map[string]interface{}{
+		{"Id": 1, "Name": "Feature 1"},
+		{"Id": 2, "Name": "Feature 2"},
+	}
+
+	if err := f.Format(&out, data, nil); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	want := "1: Feature 1\n2: Feature 2\n"
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
+
+func TestNewTemplateFormatterRequiresTemplateOrFile(t *testing.T) {
+	if _, err := newTemplateFormatter(Options{}); err == nil {
+		t.Fatal("expected error when neither Template nor TemplateFile is set, got none")
+	}
+}
+
+func TestNewTemplateFormatterRejectsInvalidSyntax(t *testing.T) {
+	if _, err := newTemplateFormatter(Options{Template: "{{.Id"}); err == nil {
+		t.Fatal("expected error for invalid template syntax, got none")
+	}
+}
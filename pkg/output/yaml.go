@@ -0,0 +1,20 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlFormatter renders data as a single YAML document.
+type yamlFormatter struct{}
+
+func (yamlFormatter) Format(w io.Writer, data interface{}, fields []string) error {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("formatting yaml: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
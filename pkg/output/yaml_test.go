@@ -0,0 +1,20 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestYAMLFormatterRendersMap(t *testing.T) {
+	var out bytes.Buffer
+	data := map[string]interface{}{"Id": 1, "Name": "Feature 1"}
+
+	if err := (yamlFormatter{}).Format(&out, data, nil); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Name: Feature 1") {
+		t.Errorf("expected yaml output to contain Name field, got %q", out.String())
+	}
+}
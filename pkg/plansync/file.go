@@ -0,0 +1,71 @@
+package plansync
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// EntityPath returns the path (relative to the plan root for a given
+// release/team) that an entity's tracked file lives at:
+// <entity-type>/<id>.json.
+func EntityPath(e Entity) string {
+	return filepath.Join(e.Type, e.ID+".json")
+}
+
+// WriteEntityFile writes an entity as deterministic, pretty-printed JSON
+// under dir, creating parent directories as needed. Deterministic output
+// (sorted keys, stable indentation) keeps `git diff` limited to the fields
+// that actually changed.
+func WriteEntityFile(dir string, e Entity) error {
+	path := filepath.Join(dir, EntityPath(e))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(e.Fields, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadSnapshot reads every <entity-type>/<id>.json file under dir (one of
+// the entityTypes subdirectories) into a Snapshot. A missing entityType
+// directory is treated as empty rather than an error, since a freshly
+// initialized plan won't have one yet.
+func ReadSnapshot(dir string, entityTypes []string) (Snapshot, error) {
+	entities := []Entity{}
+
+	for _, entityType := range entityTypes {
+		typeDir := filepath.Join(dir, entityType)
+		files, err := os.ReadDir(typeDir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+				continue
+			}
+			id := f.Name()[:len(f.Name())-len(".json")]
+
+			data, err := os.ReadFile(filepath.Join(typeDir, f.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			var fields map[string]interface{}
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return nil, err
+			}
+
+			entities = append(entities, Entity{Type: entityType, ID: id, Fields: fields})
+		}
+	}
+
+	return NewSnapshot(entities), nil
+}
@@ -0,0 +1,48 @@
+package plansync
+
+import (
+	"testing"
+)
+
+func TestWriteAndReadSnapshotRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+
+	entities := []Entity{
+		{Type: "Feature", ID: "1", Fields: fields("name", "User Auth", "effort", float64(21))},
+		{Type: "TeamPIObjective", ID: "2", Fields: fields("name", "API Perf")},
+	}
+
+	for _, e := range entities {
+		if err := WriteEntityFile(dir, e); err != nil {
+			t.Fatalf("WriteEntityFile: %v", err)
+		}
+	}
+
+	snapshot, err := ReadSnapshot(dir, []string{"Feature", "TeamPIObjective"})
+	if err != nil {
+		t.Fatalf("ReadSnapshot: %v", err)
+	}
+
+	if len(snapshot) != 2 {
+		t.Fatalf("expected 2 entities, got %d", len(snapshot))
+	}
+	got, ok := snapshot[Key("Feature", "1")]
+	if !ok {
+		t.Fatalf("expected Feature/1 in snapshot, got %v", snapshot)
+	}
+	if got.Fields["name"] != "User Auth" || got.Fields["effort"] != float64(21) {
+		t.Errorf("unexpected fields for Feature/1: %v", got.Fields)
+	}
+}
+
+func TestReadSnapshotMissingEntityTypeDirIsEmptyNotError(t *testing.T) {
+	dir := t.TempDir()
+
+	snapshot, err := ReadSnapshot(dir, []string{"Feature"})
+	if err != nil {
+		t.Fatalf("expected no error for a missing directory, got %v", err)
+	}
+	if len(snapshot) != 0 {
+		t.Errorf("expected an empty snapshot, got %v", snapshot)
+	}
+}
@@ -0,0 +1,261 @@
+// Package plansync implements the diff and 3-way merge logic behind
+// `tpcli plan push`: given the plan state at the last pull (base), the
+// locally edited plan state (ours), and the current state on the
+// TargetProcess server (theirs), it computes the minimal set of
+// Create/Update/Delete calls needed to reconcile them, merging concurrent
+// edits to different fields of the same entity instead of conflicting on
+// the whole record.
+package plansync
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Op identifies the kind of change a Change represents.
+type Op int
+
+const (
+	// OpAdd means the entity should be created.
+	OpAdd Op = iota
+	// OpModify means the entity should be updated with Fields.
+	OpModify
+	// OpDelete means the entity should be deleted.
+	OpDelete
+)
+
+// Entity is one TeamPIObjective/Feature record as tracked on disk under
+// plan/<release>/<team>/<entity-type>/<id>.json.
+type Entity struct {
+	Type   string
+	ID     string
+	Fields map[string]interface{}
+}
+
+// Snapshot is the set of entities known at some point in time, keyed by
+// Type+"/"+ID so that a file rename (e.g. moved between directories but
+// keeping the same id.json name and content) is recognized as the same
+// entity rather than a delete-then-add.
+type Snapshot map[string]Entity
+
+// Key returns the Snapshot key for an entity type and ID.
+func Key(entityType, id string) string {
+	return entityType + "/" + id
+}
+
+// NewSnapshot builds a Snapshot from a list of entities.
+func NewSnapshot(entities []Entity) Snapshot {
+	s := make(Snapshot, len(entities))
+	for _, e := range entities {
+		s[Key(e.Type, e.ID)] = e
+	}
+	return s
+}
+
+// Change is one API call plan push needs to make to reconcile the server
+// with the locally edited plan.
+type Change struct {
+	Op         Op
+	EntityType string
+	ID         string
+	// Fields holds the full record for OpAdd, or only the fields that
+	// changed for OpModify. It is nil for OpDelete.
+	Fields map[string]interface{}
+}
+
+// ConflictKind identifies why a Conflict could not be resolved automatically.
+type ConflictKind int
+
+const (
+	// ConflictField means the same field was changed to different values
+	// on both sides since base.
+	ConflictField ConflictKind = iota
+	// ConflictDeletedRemotely means the entity was deleted on the server
+	// since base, but is still being edited locally.
+	ConflictDeletedRemotely
+	// ConflictAddedBothSides means an entity with the same id was added
+	// independently both locally and on the server since base.
+	ConflictAddedBothSides
+)
+
+// Conflict describes an entity that Reconcile could not merge automatically.
+// Callers should surface these to the user instead of pushing a Change for
+// them.
+type Conflict struct {
+	EntityType string
+	ID         string
+	Kind       ConflictKind
+	// Fields holds the names of the conflicting fields, for ConflictField.
+	Fields []string
+}
+
+// Reconcile computes the Changes needed to bring the server (theirs) in
+// line with the local edits (ours), relative to the last-synced state
+// (base). Fields changed on only one side since base are carried over
+// automatically; fields changed differently on both sides are reported as
+// Conflicts instead of being silently overwritten.
+func Reconcile(base, ours, theirs Snapshot) ([]Change, []Conflict) {
+	var changes []Change
+	var conflicts []Conflict
+
+	for _, key := range unionKeys(base, ours, theirs) {
+		b, bok := base[key]
+		o, ook := ours[key]
+		t, tok := theirs[key]
+
+		switch {
+		case !bok && ook && !tok:
+			changes = append(changes, Change{Op: OpAdd, EntityType: o.Type, ID: o.ID, Fields: o.Fields})
+
+		case !bok && ook && tok:
+			conflicts = append(conflicts, Conflict{EntityType: o.Type, ID: o.ID, Kind: ConflictAddedBothSides})
+
+		case bok && ook && !tok:
+			conflicts = append(conflicts, Conflict{EntityType: b.Type, ID: b.ID, Kind: ConflictDeletedRemotely})
+
+		case bok && !ook && tok:
+			changes = append(changes, Change{Op: OpDelete, EntityType: b.Type, ID: b.ID})
+
+		case bok && !ook && !tok:
+			// Deleted on both sides since base: nothing to push.
+
+		case !bok && !ook && tok:
+			// Exists only on the server (added there since base): nothing
+			// for this plan to push.
+
+		default:
+			merged, fieldConflicts := Merge3(b.Fields, o.Fields, t.Fields)
+			if len(fieldConflicts) > 0 {
+				conflicts = append(conflicts, Conflict{EntityType: o.Type, ID: o.ID, Kind: ConflictField, Fields: fieldConflicts})
+			}
+			if changed := changedFields(t.Fields, merged); len(changed) > 0 {
+				changes = append(changes, Change{Op: OpModify, EntityType: o.Type, ID: o.ID, Fields: changed})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].EntityType != changes[j].EntityType {
+			return changes[i].EntityType < changes[j].EntityType
+		}
+		return changes[i].ID < changes[j].ID
+	})
+
+	return changes, conflicts
+}
+
+// Merge3 performs a field-level 3-way merge of an entity's fields: base is
+// the state at the last pull, ours is the local edit, theirs is the current
+// server state. A field changed on only one side wins; a field changed
+// identically on both sides is kept; a field changed to different values on
+// both sides is reported as a conflict (ours wins in merged, so push still
+// has a deterministic value to offer, but the conflict must be surfaced to
+// the user rather than silently sent).
+func Merge3(base, ours, theirs map[string]interface{}) (merged map[string]interface{}, conflicts []string) {
+	merged = map[string]interface{}{}
+
+	for _, k := range unionFieldKeys(base, ours, theirs) {
+		bv, bok := base[k]
+		ov, ook := ours[k]
+		tv, tok := theirs[k]
+
+		oursChanged := !fieldEqual(bv, bok, ov, ook)
+		theirsChanged := !fieldEqual(bv, bok, tv, tok)
+
+		switch {
+		case oursChanged && theirsChanged:
+			if fieldEqual(ov, ook, tv, tok) {
+				if ook {
+					merged[k] = ov
+				}
+			} else {
+				conflicts = append(conflicts, k)
+				if ook {
+					merged[k] = ov
+				}
+			}
+		case oursChanged:
+			if ook {
+				merged[k] = ov
+			}
+		case theirsChanged:
+			if tok {
+				merged[k] = tv
+			}
+		default:
+			if bok {
+				merged[k] = bv
+			}
+		}
+	}
+
+	sort.Strings(conflicts)
+	return merged, conflicts
+}
+
+// changedFields returns the subset of merged whose value differs from (or is
+// absent from) from_, i.e. the fields an Update call actually needs to send.
+func changedFields(from_, merged map[string]interface{}) map[string]interface{} {
+	changed := map[string]interface{}{}
+	for k, v := range merged {
+		fv, fok := from_[k]
+		if !fieldEqual(fv, fok, v, true) {
+			changed[k] = v
+		}
+	}
+	return changed
+}
+
+func fieldEqual(a interface{}, aok bool, b interface{}, bok bool) bool {
+	if aok != bok {
+		return false
+	}
+	if !aok {
+		return true
+	}
+	return deepEqual(a, b)
+}
+
+// deepEqual compares two decoded-JSON values by their canonical encoding:
+// encoding/json already sorts map keys, so this is a cheap, order-independent
+// deep comparison without reaching for reflect.DeepEqual (which would treat
+// e.g. int(1) and float64(1) as different, a distinction that doesn't exist
+// once both sides have round-tripped through JSON).
+func deepEqual(a, b interface{}) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	if aerr != nil || berr != nil {
+		return false
+	}
+	return string(aj) == string(bj)
+}
+
+func unionFieldKeys(maps ...map[string]interface{}) []string {
+	seen := map[string]struct{}{}
+	for _, m := range maps {
+		for k := range m {
+			seen[k] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionKeys(snapshots ...Snapshot) []string {
+	seen := map[string]struct{}{}
+	for _, s := range snapshots {
+		for k := range s {
+			seen[k] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,197 @@
+package plansync
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func fields(pairs ...interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	for i := 0; i < len(pairs); i += 2 {
+		m[pairs[i].(string)] = pairs[i+1]
+	}
+	return m
+}
+
+func TestReconcileAdd(t *testing.T) {
+	base := Snapshot{}
+	ours := NewSnapshot([]Entity{{Type: "Feature", ID: "1", Fields: fields("name", "User Auth")}})
+	theirs := Snapshot{}
+
+	changes, conflicts := Reconcile(base, ours, theirs)
+
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+	if len(changes) != 1 || changes[0].Op != OpAdd || changes[0].ID != "1" {
+		t.Fatalf("expected single Add for id 1, got %+v", changes)
+	}
+	if changes[0].Fields["name"] != "User Auth" {
+		t.Errorf("expected Add to carry full fields, got %v", changes[0].Fields)
+	}
+}
+
+func TestReconcileFieldLevelModify(t *testing.T) {
+	base := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("name", "API Perf", "effort", float64(34))}})
+	ours := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("name", "API Perf", "effort", float64(40))}})
+	theirs := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("name", "API Perf", "effort", float64(34))}})
+
+	changes, conflicts := Reconcile(base, ours, theirs)
+
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+	if len(changes) != 1 || changes[0].Op != OpModify {
+		t.Fatalf("expected a single Modify, got %+v", changes)
+	}
+	if len(changes[0].Fields) != 1 || changes[0].Fields["effort"] != float64(40) {
+		t.Errorf("expected only the changed field (effort=40), got %v", changes[0].Fields)
+	}
+}
+
+func TestReconcileFieldLevelModifyMergesConcurrentEditsToDifferentFields(t *testing.T) {
+	base := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("name", "API Perf", "effort", float64(34))}})
+	// Local edit changes effort only.
+	ours := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("name", "API Perf", "effort", float64(40))}})
+	// Server-side edit (since base) changed name only.
+	theirs := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("name", "API Performance", "effort", float64(34))}})
+
+	changes, conflicts := Reconcile(base, ours, theirs)
+
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts for edits to different fields, got %v", conflicts)
+	}
+	if len(changes) != 1 || changes[0].Op != OpModify {
+		t.Fatalf("expected a single Modify, got %+v", changes)
+	}
+	// Only effort should be pushed: name already matches theirs (the
+	// server's own concurrent edit), pushing it again would be a no-op at
+	// best and a silent overwrite of the server's edit at worst.
+	if _, ok := changes[0].Fields["name"]; ok {
+		t.Errorf("did not expect 'name' in the pushed fields, got %v", changes[0].Fields)
+	}
+	if changes[0].Fields["effort"] != float64(40) {
+		t.Errorf("expected effort=40 to be pushed, got %v", changes[0].Fields)
+	}
+}
+
+func TestReconcileFieldConflict(t *testing.T) {
+	base := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("effort", float64(34))}})
+	ours := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("effort", float64(40))}})
+	theirs := NewSnapshot([]Entity{{Type: "TeamPIObjective", ID: "1", Fields: fields("effort", float64(21))}})
+
+	changes, conflicts := Reconcile(base, ours, theirs)
+
+	if len(conflicts) != 1 || conflicts[0].Kind != ConflictField {
+		t.Fatalf("expected a single field conflict, got %+v", conflicts)
+	}
+	if !reflect.DeepEqual(conflicts[0].Fields, []string{"effort"}) {
+		t.Errorf("expected the conflict to name 'effort', got %v", conflicts[0].Fields)
+	}
+	_ = changes
+}
+
+func TestReconcileDelete(t *testing.T) {
+	base := NewSnapshot([]Entity{{Type: "Feature", ID: "1", Fields: fields("name", "User Auth")}})
+	ours := Snapshot{}
+	theirs := NewSnapshot([]Entity{{Type: "Feature", ID: "1", Fields: fields("name", "User Auth")}})
+
+	changes, conflicts := Reconcile(base, ours, theirs)
+
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+	if len(changes) != 1 || changes[0].Op != OpDelete || changes[0].ID != "1" {
+		t.Fatalf("expected single Delete for id 1, got %+v", changes)
+	}
+}
+
+func TestReconcileDeletedRemotelyIsConflictNotResurrection(t *testing.T) {
+	base := NewSnapshot([]Entity{{Type: "Feature", ID: "1", Fields: fields("name", "User Auth")}})
+	ours := NewSnapshot([]Entity{{Type: "Feature", ID: "1", Fields: fields("name", "User Auth v2")}})
+	theirs := Snapshot{} // deleted on the server since base
+
+	changes, conflicts := Reconcile(base, ours, theirs)
+
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes for an entity deleted remotely, got %+v", changes)
+	}
+	if len(conflicts) != 1 || conflicts[0].Kind != ConflictDeletedRemotely {
+		t.Fatalf("expected a ConflictDeletedRemotely, got %+v", conflicts)
+	}
+}
+
+// TestReconcileRenameByID verifies that an entity which moved on disk (e.g.
+// from one plan directory to another) but kept the same type+id and fields
+// is recognized as unchanged, not as a delete-then-add pair, since Snapshot
+// keys purely on type+id and is indifferent to the file path the caller
+// read it from.
+func TestReconcileRenameByID(t *testing.T) {
+	entity := Entity{Type: "Feature", ID: "42", Fields: fields("name", "User Auth")}
+	base := NewSnapshot([]Entity{entity})
+	// Same entity, same id, re-read from a different source path - the
+	// caller is responsible for assigning Type/ID, not the file location,
+	// so this models a rename/move that left content untouched.
+	ours := NewSnapshot([]Entity{entity})
+	theirs := NewSnapshot([]Entity{entity})
+
+	changes, conflicts := Reconcile(base, ours, theirs)
+
+	if len(changes) != 0 {
+		t.Errorf("expected no changes for an unmodified renamed entity, got %+v", changes)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+}
+
+func TestMerge3IdenticalChangeOnBothSidesIsNotAConflict(t *testing.T) {
+	base := fields("effort", float64(34))
+	ours := fields("effort", float64(40))
+	theirs := fields("effort", float64(40))
+
+	merged, conflicts := Merge3(base, ours, theirs)
+
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+	if merged["effort"] != float64(40) {
+		t.Errorf("expected merged effort to be 40, got %v", merged["effort"])
+	}
+}
+
+func TestMerge3FieldRemovedLocally(t *testing.T) {
+	base := fields("effort", float64(34), "description", "old")
+	ours := fields("effort", float64(34))
+	theirs := fields("effort", float64(34), "description", "old")
+
+	merged, conflicts := Merge3(base, ours, theirs)
+
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+	if _, ok := merged["description"]; ok {
+		t.Errorf("expected 'description' to be dropped, got %v", merged)
+	}
+}
+
+func TestReconcileSortsChangesByTypeThenID(t *testing.T) {
+	ours := NewSnapshot([]Entity{
+		{Type: "Feature", ID: "2", Fields: fields("name", "B")},
+		{Type: "Feature", ID: "1", Fields: fields("name", "A")},
+		{Type: "TeamPIObjective", ID: "1", Fields: fields("name", "C")},
+	})
+
+	changes, _ := Reconcile(Snapshot{}, ours, Snapshot{})
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.EntityType+"/"+c.ID)
+	}
+	want := []string{"Feature/1", "Feature/2", "TeamPIObjective/1"}
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, []string{"Feature/1", "Feature/2", "TeamPIObjective/1"}) {
+		t.Errorf("expected changes sorted by type then id, got %v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultPath returns the default on-disk location for the schema cache,
+// ~/.cache/tpcli/schema.json, honoring $XDG_CACHE_HOME when set.
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving cache directory: %w", err)
+	}
+	return filepath.Join(dir, "tpcli", "schema.json"), nil
+}
+
+// Load reads and parses a schema cache file.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema cache %s: %w", path, err)
+	}
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing schema cache %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save writes s to path as pretty-printed JSON, creating parent directories
+// as needed.
+func Save(path string, s *Schema) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating schema cache directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding schema cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing schema cache %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "schema.json")
+
+	want := &Schema{
+		GeneratedAt: "2026-01-01T00:00:00Z",
+		EntityTypes: map[string]EntityTypeSchema{
+			"Bug": {Count: 3, Fields: map[string]FieldSchema{"Name": {Type: "string"}}},
+		},
+	}
+
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got.GeneratedAt != want.GeneratedAt {
+		t.Errorf("GeneratedAt = %q, want %q", got.GeneratedAt, want.GeneratedAt)
+	}
+	if got.EntityTypes["Bug"].Count != 3 {
+		t.Errorf("Bug.Count = %d, want 3", got.EntityTypes["Bug"].Count)
+	}
+}
+
+func TestLoadMissingFileErrors(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error loading a missing schema cache")
+	}
+}
+
+func TestDefaultPathEndsInSchemaJSON(t *testing.T) {
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath: %v", err)
+	}
+	if filepath.Base(path) != "schema.json" {
+		t.Errorf("DefaultPath = %s, want a path ending in schema.json", path)
+	}
+}
@@ -0,0 +1,95 @@
+// Package schema models the entity-type/field shape that `tpcli discover`
+// finds in a TargetProcess instance, so it can be cached to disk and reused
+// by other commands for validation and shell completion.
+package schema
+
+// Schema is the on-disk representation of a discover run: every entity
+// type that was reachable, and the fields seen on its sample data.
+type Schema struct {
+	GeneratedAt string                      `json:"generated_at"`
+	EntityTypes map[string]EntityTypeSchema `json:"entity_types"`
+}
+
+// EntityTypeSchema describes one entity type's shape.
+type EntityTypeSchema struct {
+	Count  int                    `json:"count"`
+	Fields map[string]FieldSchema `json:"fields"`
+}
+
+// FieldSchema describes a single field inferred from a sample value. Type is
+// one of "string", "number", "bool", "array", "object" or "null". EntityType
+// is set when the field's sample value was a nested TargetProcess resource
+// (an object carrying a "ResourceType"), naming the related entity type so
+// callers can walk the relationship.
+type FieldSchema struct {
+	Type       string `json:"type"`
+	EntityType string `json:"entity_type,omitempty"`
+}
+
+// InferField builds a FieldSchema from a sample field value, recognizing
+// TargetProcess's convention of embedding a "ResourceType" key on nested
+// resource references (e.g. Project, AssignedUser).
+func InferField(v interface{}) FieldSchema {
+	switch val := v.(type) {
+	case nil:
+		return FieldSchema{Type: "null"}
+	case bool:
+		return FieldSchema{Type: "bool"}
+	case float64:
+		return FieldSchema{Type: "number"}
+	case string:
+		return FieldSchema{Type: "string"}
+	case []interface{}:
+		return FieldSchema{Type: "array"}
+	case map[string]interface{}:
+		fs := FieldSchema{Type: "object"}
+		if rt, ok := val["ResourceType"].(string); ok {
+			fs.EntityType = rt
+		}
+		return fs
+	default:
+		return FieldSchema{Type: "object"}
+	}
+}
+
+// FieldNames returns the known field names for entityType, or nil if the
+// type isn't in the schema.
+func (s *Schema) FieldNames(entityType string) []string {
+	et, ok := s.EntityTypes[entityType]
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(et.Fields))
+	for name := range et.Fields {
+		names = append(names, name)
+	}
+	return names
+}
+
+// EntityTypeNames returns every entity type name in the schema.
+func (s *Schema) EntityTypeNames() []string {
+	names := make([]string, 0, len(s.EntityTypes))
+	for name := range s.EntityTypes {
+		names = append(names, name)
+	}
+	return names
+}
+
+// HasEntityType reports whether entityType is present in the schema.
+func (s *Schema) HasEntityType(entityType string) bool {
+	_, ok := s.EntityTypes[entityType]
+	return ok
+}
+
+// HasField reports whether entityType has a known field named field. An
+// unknown entity type is treated as "nothing to validate against" and
+// reports true, so commands don't reject fields for types discover hasn't
+// seen yet.
+func (s *Schema) HasField(entityType, field string) bool {
+	et, ok := s.EntityTypes[entityType]
+	if !ok {
+		return true
+	}
+	_, ok = et.Fields[field]
+	return ok
+}
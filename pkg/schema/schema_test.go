@@ -0,0 +1,47 @@
+package schema
+
+import "testing"
+
+func TestInferFieldRecognizesPrimitiveTypes(t *testing.T) {
+	cases := map[string]interface{}{
+		"string": "hi",
+		"number": float64(42),
+		"bool":   true,
+		"array":  []interface{}{1, 2},
+		"null":   nil,
+	}
+	for wantType, v := range cases {
+		if got := InferField(v).Type; got != wantType {
+			t.Errorf("InferField(%v) = %q, want %q", v, got, wantType)
+		}
+	}
+}
+
+func TestInferFieldCapturesNestedResourceType(t *testing.T) {
+	fs := InferField(map[string]interface{}{"ResourceType": "Project", "Id": float64(1)})
+	if fs.Type != "object" {
+		t.Errorf("Type = %q, want object", fs.Type)
+	}
+	if fs.EntityType != "Project" {
+		t.Errorf("EntityType = %q, want Project", fs.EntityType)
+	}
+}
+
+func TestSchemaHasFieldAllowsUnknownEntityType(t *testing.T) {
+	s := &Schema{EntityTypes: map[string]EntityTypeSchema{}}
+	if !s.HasField("Bug", "Name") {
+		t.Error("expected HasField to allow unknown entity types through")
+	}
+}
+
+func TestSchemaHasFieldRejectsUnknownField(t *testing.T) {
+	s := &Schema{EntityTypes: map[string]EntityTypeSchema{
+		"Bug": {Fields: map[string]FieldSchema{"Name": {Type: "string"}}},
+	}}
+	if !s.HasField("Bug", "Name") {
+		t.Error("expected Name to be a known field")
+	}
+	if s.HasField("Bug", "Nmae") {
+		t.Error("expected Nmae to be rejected")
+	}
+}
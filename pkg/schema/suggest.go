@@ -0,0 +1,70 @@
+package schema
+
+// SuggestEntityType returns the closest known entity type name to name, for
+// a "did you mean" hint, and false if nothing is close enough to be useful.
+func (s *Schema) SuggestEntityType(name string) (string, bool) {
+	return suggest(name, s.EntityTypeNames())
+}
+
+// SuggestField returns the closest known field name on entityType to field,
+// and false if nothing is close enough to be useful.
+func (s *Schema) SuggestField(entityType, field string) (string, bool) {
+	return suggest(field, s.FieldNames(entityType))
+}
+
+// suggest returns the candidate closest to name by edit distance, as long as
+// the distance is small relative to name's length (otherwise any suggestion
+// would be more confusing than helpful).
+func suggest(name string, candidates []string) (string, bool) {
+	best := ""
+	bestDist := -1
+	for _, c := range candidates {
+		d := levenshtein(name, c)
+		if bestDist == -1 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if bestDist == -1 {
+		return "", false
+	}
+	maxLen := len(name)
+	if len(best) > maxLen {
+		maxLen = len(best)
+	}
+	if maxLen == 0 || bestDist > (maxLen/2+1) {
+		return "", false
+	}
+	return best, true
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			del := prev[j] + 1
+			ins := curr[j-1] + 1
+			sub := prev[j-1] + cost
+			min := del
+			if ins < min {
+				min = ins
+			}
+			if sub < min {
+				min = sub
+			}
+			curr[j] = min
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
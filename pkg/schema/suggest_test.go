@@ -0,0 +1,32 @@
+package schema
+
+import "testing"
+
+func TestSuggestEntityTypeFindsCloseTypo(t *testing.T) {
+	s := &Schema{EntityTypes: map[string]EntityTypeSchema{
+		"UserStories": {}, "Bugs": {}, "Features": {},
+	}}
+	got, ok := s.SuggestEntityType("UserStory")
+	if !ok || got != "UserStories" {
+		t.Errorf("SuggestEntityType(UserStory) = %q, %v, want UserStories, true", got, ok)
+	}
+}
+
+func TestSuggestFieldFindsCloseTypo(t *testing.T) {
+	s := &Schema{EntityTypes: map[string]EntityTypeSchema{
+		"Bug": {Fields: map[string]FieldSchema{"EntityState": {}, "Name": {}}},
+	}}
+	got, ok := s.SuggestField("Bug", "EntitySate")
+	if !ok || got != "EntityState" {
+		t.Errorf("SuggestField(EntitySate) = %q, %v, want EntityState, true", got, ok)
+	}
+}
+
+func TestSuggestReturnsFalseWhenNothingIsClose(t *testing.T) {
+	s := &Schema{EntityTypes: map[string]EntityTypeSchema{
+		"Bug": {Fields: map[string]FieldSchema{"Name": {}}},
+	}}
+	if _, ok := s.SuggestField("Bug", "CompletelyUnrelatedWord"); ok {
+		t.Error("expected no suggestion for a completely unrelated field")
+	}
+}
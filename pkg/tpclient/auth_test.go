@@ -0,0 +1,81 @@
+package tpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQueryTokenAuthenticatorAppliesAccessToken(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://example.com/api/v1/Feature", nil)
+
+	if err := NewQueryTokenAuthenticator("tok123").Apply(req); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	if got := req.URL.Query().Get("access_token"); got != "tok123" {
+		t.Errorf("expected access_token=tok123, got %q", got)
+	}
+}
+
+func TestBasicAuthenticatorSetsCredentials(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://example.com/api/v1/Feature", nil)
+
+	if err := NewBasicAuthenticator("alice", "s3cr3t").Apply(req); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	user, pass, ok := req.BasicAuth()
+	if !ok || user != "alice" || pass != "s3cr3t" {
+		t.Errorf("expected basic auth alice:s3cr3t, got %q:%q (ok=%v)", user, pass, ok)
+	}
+}
+
+func TestBearerAuthenticatorSetsAuthorizationHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://example.com/api/v1/Feature", nil)
+
+	if err := NewBearerAuthenticator("tok456").Apply(req); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	if got := req.Header.Get("Authorization"); got != "Bearer tok456" {
+		t.Errorf("expected Authorization: Bearer tok456, got %q", got)
+	}
+}
+
+func TestCookieAuthenticatorAttachesCookie(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://example.com/api/v1/Feature", nil)
+
+	if err := NewCookieAuthenticator("session", "abc").Apply(req); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	cookie, err := req.Cookie("session")
+	if err != nil {
+		t.Fatalf("expected session cookie, got error: %v", err)
+	}
+	if cookie.Value != "abc" {
+		t.Errorf("expected cookie value abc, got %q", cookie.Value)
+	}
+}
+
+func TestClientDoRequestUsesConfiguredAuthenticator(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "ignored", false)
+	client.SetAuthenticator(NewBearerAuthenticator("swapped-in"))
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if gotAuth != "Bearer swapped-in" {
+		t.Errorf("expected Authorization: Bearer swapped-in, got %q", gotAuth)
+	}
+}
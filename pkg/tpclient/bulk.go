@@ -0,0 +1,122 @@
+package tpclient
+
+import "sync"
+
+// BulkUpdateItem pairs an entity ID with the fields to change, for use with
+// BulkUpdate.
+type BulkUpdateItem struct {
+	ID   string
+	Data []byte
+}
+
+// ItemResult records the outcome of a single item in a BulkCreate or
+// BulkUpdate call. Index is the item's position in the input slice, so
+// callers can correlate a result back to the manifest entry that produced it
+// even after Succeeded/Failed have been split out.
+type ItemResult struct {
+	Index  int
+	ID     string
+	Result []byte
+	Err    error
+}
+
+// BulkResult is the aggregate outcome of a BulkCreate or BulkUpdate call.
+// Succeeded and Failed each preserve the relative input order of the items
+// that landed in them.
+type BulkResult struct {
+	Succeeded []ItemResult
+	Failed    []ItemResult
+}
+
+// BulkCreate creates many entities of the same type concurrently, bounded by
+// WithConcurrency (default 1, i.e. sequential). Unlike Create, BulkCreate
+// never returns an error for individual item failures: a non-2xx response or
+// invalid JSON for one item is recorded in BulkResult.Failed and the rest of
+// the batch still runs to completion, since aborting a PI's worth of pushed
+// objectives on the first rejected item would leave the batch in an unclear
+// partial state.
+func (c *Client) BulkCreate(entityType string, items [][]byte, opts ...Option) (BulkResult, error) {
+	results := make([]ItemResult, len(items))
+
+	Run(len(items), concurrencyOf(opts), func(i int) {
+		data, err := c.Create(entityType, items[i], opts...)
+		results[i] = ItemResult{Index: i, Result: data, Err: err}
+	})
+
+	return splitResults(results), nil
+}
+
+// BulkUpdate updates many entities concurrently, bounded by WithConcurrency
+// (default 1). See BulkCreate for the partial-failure behaviour.
+func (c *Client) BulkUpdate(entityType string, updates []BulkUpdateItem, opts ...Option) (BulkResult, error) {
+	results := make([]ItemResult, len(updates))
+
+	Run(len(updates), concurrencyOf(opts), func(i int) {
+		data, err := c.Update(entityType, updates[i].ID, updates[i].Data, opts...)
+		results[i] = ItemResult{Index: i, ID: updates[i].ID, Result: data, Err: err}
+	})
+
+	return splitResults(results), nil
+}
+
+// BulkDelete deletes many entities concurrently, bounded by WithConcurrency
+// (default 1). See BulkCreate for the partial-failure behaviour.
+func (c *Client) BulkDelete(entityType string, ids []string, opts ...Option) (BulkResult, error) {
+	results := make([]ItemResult, len(ids))
+
+	Run(len(ids), concurrencyOf(opts), func(i int) {
+		err := c.Delete(entityType, ids[i], opts...)
+		results[i] = ItemResult{Index: i, ID: ids[i], Err: err}
+	})
+
+	return splitResults(results), nil
+}
+
+// concurrencyOf extracts the WithConcurrency setting from opts without
+// triggering any of the other per-request side effects (idempotency key,
+// headers, timeout) those opts carry.
+func concurrencyOf(opts []Option) int {
+	cfg := &requestConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.concurrency < 1 {
+		return 1
+	}
+	return cfg.concurrency
+}
+
+// Run fans work out across a bounded worker pool, calling fn(i) for every i
+// in [0, n) and waiting for all calls to complete before returning. It's
+// exported so callers outside tpclient (e.g. cmd's plan apply dispatcher)
+// can reuse the same worker-pool logic instead of hand-rolling their own.
+func Run(n, concurrency int, fn func(i int)) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// splitResults partitions results (in index order) into BulkResult's
+// Succeeded and Failed slices, preserving input order within each.
+func splitResults(results []ItemResult) BulkResult {
+	var out BulkResult
+	for _, r := range results {
+		if r.Err != nil {
+			out.Failed = append(out.Failed, r)
+		} else {
+			out.Succeeded = append(out.Succeeded, r)
+		}
+	}
+	return out
+}
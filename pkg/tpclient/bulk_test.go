@@ -0,0 +1,179 @@
+package tpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// TestClientBulkCreatePartialFailure verifies that a rejected item doesn't
+// abort the rest of the batch and lands in BulkResult.Failed.
+func TestClientBulkCreatePartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		if string(body) == `{"name":"bad"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"Status":"BadRequest","Message":"nope","Type":"ValidationException"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	items := [][]byte{
+		[]byte(`{"name":"good1"}`),
+		[]byte(`{"name":"bad"}`),
+		[]byte(`{"name":"good2"}`),
+	}
+
+	result, err := client.BulkCreate("TeamPIObjective", items)
+	if err != nil {
+		t.Fatalf("expected no top-level error, got %v", err)
+	}
+
+	if len(result.Succeeded) != 2 {
+		t.Errorf("expected 2 succeeded, got %d", len(result.Succeeded))
+	}
+	if len(result.Failed) != 1 {
+		t.Errorf("expected 1 failed, got %d", len(result.Failed))
+	}
+	if len(result.Failed) == 1 && result.Failed[0].Index != 1 {
+		t.Errorf("expected failed item at index 1, got %d", result.Failed[0].Index)
+	}
+}
+
+// TestClientBulkCreatePreservesInputOrder verifies Succeeded entries keep
+// their original input order even though work runs concurrently.
+func TestClientBulkCreatePreservesInputOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	items := make([][]byte, 20)
+	for i := range items {
+		items[i] = []byte(fmt.Sprintf(`{"name":"item-%d"}`, i))
+	}
+
+	result, err := client.BulkCreate("TeamPIObjective", items, WithConcurrency(8))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(result.Succeeded) != len(items) {
+		t.Fatalf("expected %d succeeded, got %d", len(items), len(result.Succeeded))
+	}
+	for i, r := range result.Succeeded {
+		if r.Index != i {
+			t.Errorf("expected succeeded[%d].Index == %d, got %d", i, i, r.Index)
+		}
+	}
+}
+
+// TestClientBulkCreateRespectsConcurrencyLimit verifies that no more than
+// WithConcurrency(n) requests are in flight at once.
+func TestClientBulkCreateRespectsConcurrencyLimit(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	items := make([][]byte, 50)
+	for i := range items {
+		items[i] = []byte(`{"name":"item"}`)
+	}
+
+	const limit = 4
+	if _, err := client.BulkCreate("TeamPIObjective", items, WithConcurrency(limit)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if atomic.LoadInt32(&maxInFlight) > limit {
+		t.Errorf("expected at most %d concurrent requests, observed %d", limit, maxInFlight)
+	}
+}
+
+// TestClientBulkUpdatePartialFailure mirrors TestClientBulkCreatePartialFailure
+// for BulkUpdate.
+func TestClientBulkUpdatePartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/TeamPIObjective/2" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Status":"NotFound","Message":"missing","Type":"EntityNotFoundException"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	updates := []BulkUpdateItem{
+		{ID: "1", Data: []byte(`{"effort":1}`)},
+		{ID: "2", Data: []byte(`{"effort":2}`)},
+		{ID: "3", Data: []byte(`{"effort":3}`)},
+	}
+
+	result, err := client.BulkUpdate("TeamPIObjective", updates)
+	if err != nil {
+		t.Fatalf("expected no top-level error, got %v", err)
+	}
+
+	if len(result.Succeeded) != 2 {
+		t.Errorf("expected 2 succeeded, got %d", len(result.Succeeded))
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ID != "2" {
+		t.Errorf("expected exactly item 2 to fail, got %+v", result.Failed)
+	}
+}
+
+// TestClientBulkDeletePartialFailure mirrors TestClientBulkCreatePartialFailure
+// for BulkDelete.
+func TestClientBulkDeletePartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/TeamPIObjective/2" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Status":"NotFound","Message":"missing","Type":"EntityNotFoundException"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	result, err := client.BulkDelete("TeamPIObjective", []string{"1", "2", "3"})
+	if err != nil {
+		t.Fatalf("expected no top-level error, got %v", err)
+	}
+
+	if len(result.Succeeded) != 2 {
+		t.Errorf("expected 2 succeeded, got %d", len(result.Succeeded))
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ID != "2" {
+		t.Errorf("expected exactly item 2 to fail, got %+v", result.Failed)
+	}
+}
@@ -1,11 +1,17 @@
 package tpclient
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,38 +22,150 @@ type Client struct {
 	Token      string
 	HTTPClient *http.Client
 	Verbose    bool
+
+	// APIVersion is the API version segment used to build request URLs
+	// (e.g. "v1", "v2beta"). NewClient defaults it to "v1"; pin a
+	// different version for a single request with WithAPIVersion.
+	APIVersion string
+
+	// Transport, if set, is used as the innermost RoundTripper for every
+	// request, beneath any registered middlewares. Tests and downstream
+	// tools can set this to an in-memory fake instead of talking to a real
+	// server. Defaults to http.DefaultTransport when nil.
+	Transport http.RoundTripper
+
+	// Authenticator applies credentials to every outgoing request. It
+	// defaults to the query-string access_token scheme; set it directly,
+	// or via SetAuthenticator, to use HTTP Basic, Bearer or cookie auth
+	// instead.
+	Authenticator Authenticator
+
+	// MaxRetries bounds how many times a request is retried after a
+	// network error, a 429 Too Many Requests, or a 503 Service Unavailable.
+	// NewClient defaults it to 3; set to 0 to disable retries.
+	MaxRetries int
+
+	// RateLimiter, if set, bounds how fast the Client issues requests, so a
+	// bulk discover or ListAll run doesn't get throttled by TargetProcess.
+	// Defaults to nil (unlimited); enable it with SetRateLimit.
+	RateLimiter *RateLimiter
+
+	// Logger receives structured per-request logs: method, redacted URL,
+	// status, elapsed time and response size at LevelDebug, plus the
+	// redacted request/response bodies at LevelTrace. NewClient defaults it
+	// to a text logger on stderr, at LevelDebug if verbose was true and
+	// LevelWarn otherwise; reconfigure it with SetLogLevel/SetLogFormat, or
+	// assign Logger directly for full control.
+	Logger *slog.Logger
+
+	logLevel    slog.Level
+	logFormat   string
+	middlewares []Middleware
 }
 
-// NewClient creates a new TargetProcess API client
+// NewClient creates a new TargetProcess API client, authenticating with the
+// query-string access_token scheme by default. Call SetAuthenticator to use
+// HTTP Basic, Bearer or cookie auth instead.
 func NewClient(baseURL, token string, verbose bool) *Client {
-	return &Client{
+	logLevel := slog.LevelWarn
+	if verbose {
+		logLevel = slog.LevelDebug
+	}
+
+	c := &Client{
 		BaseURL: strings.TrimRight(baseURL, "/"),
 		Token:   token,
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		Verbose: verbose,
+		Verbose:       verbose,
+		APIVersion:    "v1",
+		Authenticator: NewQueryTokenAuthenticator(token),
+		MaxRetries:    3,
+		logLevel:      logLevel,
+		logFormat:     "text",
 	}
+	c.Logger = NewLogger(logLevel, c.logFormat)
+	c.Use(RequestIDMiddleware())
+	return c
 }
 
-// doRequest executes an HTTP request with authentication
-func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
-	fullURL := fmt.Sprintf("%s%s", c.BaseURL, path)
+// buildURL constructs a request path for entityType (and, if id is
+// non-empty, a specific entity) under the given API version.
+func buildURL(version, entityType, id string) string {
+	if id == "" {
+		return fmt.Sprintf("/api/%s/%s", version, entityType)
+	}
+	return fmt.Sprintf("/api/%s/%s/%s", version, entityType, id)
+}
 
-	if c.Verbose {
-		fmt.Printf("Request: %s %s\n", method, fullURL)
+// apiVersion resolves the version a request should use: the opts' pinned
+// WithAPIVersion if set, otherwise the client's default APIVersion.
+func (c *Client) apiVersion(opts ...Option) string {
+	cfg := &requestConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.apiVersion != "" {
+		return cfg.apiVersion
 	}
+	return c.APIVersion
+}
+
+// SetAuthenticator replaces the client's Authenticator.
+func (c *Client) SetAuthenticator(a Authenticator) {
+	c.Authenticator = a
+}
+
+// SetRateLimit enables the Client's RateLimiter, allowing up to rps
+// requests per second on average with bursts up to burst requests.
+func (c *Client) SetRateLimit(rps, burst float64) {
+	c.RateLimiter = NewRateLimiter(rps, burst)
+}
+
+// SetLogLevel reconfigures Logger's verbosity, overriding the debug/warn
+// default NewClient picked from its verbose argument.
+func (c *Client) SetLogLevel(level slog.Level) {
+	c.logLevel = level
+	c.Logger = NewLogger(c.logLevel, c.logFormat)
+}
+
+// SetLogFormat reconfigures Logger's output format, "text" or "json".
+func (c *Client) SetLogFormat(format string) {
+	c.logFormat = format
+	c.Logger = NewLogger(c.logLevel, c.logFormat)
+}
 
-	req, err := http.NewRequest(method, fullURL, body)
+// doRequest executes an HTTP request with authentication, honoring ctx for
+// cancellation across retries and rate-limit waits.
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader, opts ...Option) (*http.Response, error) {
+	cfg := &requestConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	fullURL := c.BaseURL + path
+	if u, err := url.Parse(path); err == nil && u.IsAbs() {
+		// path is already an absolute URL, e.g. a Next page link
+		// TargetProcess returned - use it as-is rather than appending it to
+		// BaseURL, which would double up the host.
+		fullURL = path
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	// Add authentication token as query parameter
-	// This is the recommended method per IBM TargetProcess documentation
-	q := req.URL.Query()
-	q.Add("access_token", c.Token)
-	req.URL.RawQuery = q.Encode()
+	if cfg.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), cfg.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	if err := c.Authenticator.Apply(req); err != nil {
+		return nil, fmt.Errorf("applying authentication: %w", err)
+	}
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
@@ -56,21 +174,55 @@ func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response,
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+	if cfg.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", cfg.idempotencyKey)
 	}
 
-	if c.Verbose {
-		fmt.Printf("Response: %d %s\n", resp.StatusCode, resp.Status)
+	for k, v := range cfg.headers {
+		req.Header.Set(k, v)
+	}
+
+	httpClient := &http.Client{
+		Transport:     c.transport(),
+		CheckRedirect: c.HTTPClient.CheckRedirect,
+		Jar:           c.HTTPClient.Jar,
+		Timeout:       c.HTTPClient.Timeout,
+	}
+	if cfg.timeout > 0 {
+		httpClient.Timeout = cfg.timeout
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
 	}
 
 	return resp, nil
 }
 
-// Get retrieves a single entity by ID
-func (c *Client) Get(entityType string, id int, fields []string) (map[string]interface{}, error) {
-	path := fmt.Sprintf("/api/v1/%s/%d", entityType, id)
+// idempotencyKey derives a stable key for a Create/Update call so that
+// retrying the same logical change (same entity, id and body) produces the
+// same Idempotency-Key header, rather than creating a duplicate entity.
+func idempotencyKey(entityType, id string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(entityType))
+	h.Write([]byte{0})
+	h.Write([]byte(id))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get retrieves a single entity by ID. It is a thin wrapper around
+// GetContext using context.Background().
+func (c *Client) Get(entityType string, id int, fields []string, opts ...Option) (map[string]interface{}, error) {
+	return c.GetContext(context.Background(), entityType, id, fields, opts...)
+}
+
+// GetContext retrieves a single entity by ID, aborting if ctx is done
+// before the request (including its retries) completes.
+func (c *Client) GetContext(ctx context.Context, entityType string, id int, fields []string, opts ...Option) (map[string]interface{}, error) {
+	path := buildURL(c.apiVersion(opts...), entityType, strconv.Itoa(id))
 
 	if len(fields) > 0 {
 		params := url.Values{}
@@ -78,7 +230,7 @@ func (c *Client) Get(entityType string, id int, fields []string) (map[string]int
 		path = fmt.Sprintf("%s?%s", path, params.Encode())
 	}
 
-	resp, err := c.doRequest("GET", path, nil)
+	resp, err := c.doRequest(ctx, "GET", path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,8 +262,15 @@ func (c *Client) Get(entityType string, id int, fields []string) (map[string]int
 	return result, nil
 }
 
-// List retrieves multiple entities with optional filtering
-func (c *Client) List(entityType string, where string, fields []string, take, skip int) ([]map[string]interface{}, error) {
+// List retrieves multiple entities with optional filtering. It is a thin
+// wrapper around ListContext using context.Background().
+func (c *Client) List(entityType string, where string, fields []string, take, skip int, opts ...Option) ([]map[string]interface{}, error) {
+	return c.ListContext(context.Background(), entityType, where, fields, take, skip, opts...)
+}
+
+// ListContext retrieves multiple entities with optional filtering, aborting
+// if ctx is done before the request (including its retries) completes.
+func (c *Client) ListContext(ctx context.Context, entityType string, where string, fields []string, take, skip int, opts ...Option) ([]map[string]interface{}, error) {
 	params := url.Values{}
 
 	if where != "" {
@@ -130,12 +289,12 @@ func (c *Client) List(entityType string, where string, fields []string, take, sk
 		params.Set("skip", fmt.Sprintf("%d", skip))
 	}
 
-	path := fmt.Sprintf("/api/v1/%s", entityType)
+	path := buildURL(c.apiVersion(opts...), entityType, "")
 	if len(params) > 0 {
 		path = fmt.Sprintf("%s?%s", path, params.Encode())
 	}
 
-	resp, err := c.doRequest("GET", path, nil)
+	resp, err := c.doRequest(ctx, "GET", path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -170,11 +329,19 @@ func (c *Client) List(entityType string, where string, fields []string, take, sk
 	return response.Items, nil
 }
 
-// Query executes a custom query against the API v2 endpoint
+// QueryV2 executes a custom query against the API v2 endpoint. It is a thin
+// wrapper around QueryV2Context using context.Background().
 func (c *Client) QueryV2(entityType, query string) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("/api/v2/%s?%s", entityType, query)
+	return c.QueryV2Context(context.Background(), entityType, query)
+}
+
+// QueryV2Context executes a custom query against the API v2 endpoint,
+// aborting if ctx is done before the request (including its retries)
+// completes.
+func (c *Client) QueryV2Context(ctx context.Context, entityType, query string) ([]map[string]interface{}, error) {
+	path := fmt.Sprintf("%s?%s", buildURL("v2", entityType, ""), query)
 
-	resp, err := c.doRequest("GET", path, nil)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -208,3 +375,132 @@ func (c *Client) QueryV2(entityType, query string) ([]map[string]interface{}, er
 
 	return response.Items, nil
 }
+
+// Create creates a new entity of the given type. data must be a JSON object
+// describing the entity's fields. An Idempotency-Key header is sent with
+// every request so that retrying a failed Create does not create a duplicate
+// entity; callers that already have a key (e.g. from a resumed bulk job) can
+// pin it with WithIdempotencyKey, otherwise one is derived from entityType
+// and data.
+func (c *Client) Create(entityType string, data []byte, opts ...Option) ([]byte, error) {
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("invalid JSON in data parameter")
+	}
+
+	path := buildURL(c.apiVersion(opts...), entityType, "")
+
+	allOpts := append([]Option{WithIdempotencyKey(idempotencyKey(entityType, "", data))}, opts...)
+
+	resp, err := c.doRequest(context.Background(), "POST", path, bytes.NewReader(data), allOpts...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var apiErr struct {
+			Status  string `json:"Status"`
+			Message string `json:"Message"`
+			Type    string `json:"Type"`
+			ErrorId string `json:"ErrorId"`
+		}
+		msg := string(respBody)
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Message != "" {
+			msg = fmt.Sprintf("%s: %s (%s)", apiErr.Status, apiErr.Message, apiErr.Type)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("API error %d: %s; resource '%s' may not exist. Try 'tpcli discover' to find available entity types", resp.StatusCode, msg, entityType)
+		}
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, msg)
+	}
+
+	return respBody, nil
+}
+
+// Update updates an existing entity by ID. id must be numeric, matching the
+// TargetProcess entity ID scheme. data must be a JSON object describing the
+// fields to change. See Create for the Idempotency-Key behaviour.
+func (c *Client) Update(entityType, id string, data []byte, opts ...Option) ([]byte, error) {
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("invalid JSON in data parameter")
+	}
+
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, fmt.Errorf("invalid ID: %s", id)
+	}
+
+	path := buildURL(c.apiVersion(opts...), entityType, id)
+
+	allOpts := append([]Option{WithIdempotencyKey(idempotencyKey(entityType, id, data))}, opts...)
+
+	resp, err := c.doRequest(context.Background(), "PUT", path, bytes.NewReader(data), allOpts...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Status  string `json:"Status"`
+			Message string `json:"Message"`
+			Type    string `json:"Type"`
+			ErrorId string `json:"ErrorId"`
+		}
+		msg := string(respBody)
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Message != "" {
+			msg = fmt.Sprintf("%s: %s (%s)", apiErr.Status, apiErr.Message, apiErr.Type)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("API error %d: %s; resource '%s' may not exist. Try 'tpcli discover' to find available entity types", resp.StatusCode, msg, entityType)
+		}
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, msg)
+	}
+
+	return respBody, nil
+}
+
+// Delete deletes an entity by ID. id must be numeric, matching the
+// TargetProcess entity ID scheme.
+func (c *Client) Delete(entityType, id string, opts ...Option) error {
+	if _, err := strconv.Atoi(id); err != nil {
+		return fmt.Errorf("invalid ID: %s", id)
+	}
+
+	path := buildURL(c.apiVersion(opts...), entityType, id)
+
+	resp, err := c.doRequest(context.Background(), "DELETE", path, nil, opts...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		var apiErr struct {
+			Status  string `json:"Status"`
+			Message string `json:"Message"`
+			Type    string `json:"Type"`
+			ErrorId string `json:"ErrorId"`
+		}
+		msg := string(body)
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+			msg = fmt.Sprintf("%s: %s (%s)", apiErr.Status, apiErr.Message, apiErr.Type)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("API error %d: %s; resource '%s' may not exist. Try 'tpcli discover' to find available entity types", resp.StatusCode, msg, entityType)
+		}
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
@@ -369,3 +369,249 @@ func TestClientCreateWithLargePayload(t *testing.T) {
 		t.Errorf("expected id 12345, got %v", entity["id"])
 	}
 }
+
+// TestClientCreateSetsIdempotencyKeyOnce verifies the Idempotency-Key header
+// is present exactly once on the outgoing request.
+func TestClientCreateSetsIdempotencyKeyOnce(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Values("Idempotency-Key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	_, err := client.Create("TeamPIObjective", []byte(`{"name":"API Perf"}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(seen) != 1 {
+		t.Fatalf("expected exactly one Idempotency-Key header, got %d: %v", len(seen), seen)
+	}
+	if seen[0] == "" {
+		t.Error("expected a non-empty Idempotency-Key")
+	}
+}
+
+// TestClientCreateIdempotencyKeyStableForIdenticalPayload verifies that two
+// Create calls with the same entity type and body derive the same key, so a
+// retried request after a network failure doesn't create a duplicate entity.
+func TestClientCreateIdempotencyKeyStableForIdenticalPayload(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+	data := []byte(`{"name":"API Perf","team_id":1935991}`)
+
+	if _, err := client.Create("TeamPIObjective", data); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.Create("TeamPIObjective", data); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected identical non-empty idempotency keys, got %v", keys)
+	}
+}
+
+// TestClientCreateWithExplicitIdempotencyKey verifies WithIdempotencyKey
+// overrides the automatically derived key.
+func TestClientCreateWithExplicitIdempotencyKey(t *testing.T) {
+	var key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	_, err := client.Create("TeamPIObjective", []byte(`{"name":"API Perf"}`), WithIdempotencyKey("my-explicit-key"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if key != "my-explicit-key" {
+		t.Errorf("expected explicit idempotency key to win, got %q", key)
+	}
+}
+
+// TestClientUpdateDifferentBodiesGetDifferentKeys verifies that changing the
+// body (or id) changes the derived idempotency key.
+func TestClientUpdateDifferentBodiesGetDifferentKeys(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":12345}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.Update("TeamPIObjective", "12345", []byte(`{"effort":34}`)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.Update("TeamPIObjective", "12345", []byte(`{"effort":40}`)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(keys) != 2 || keys[0] == keys[1] {
+		t.Errorf("expected different idempotency keys for different bodies, got %v", keys)
+	}
+}
+
+// TestClientDeleteSuccess tests successful deletion of an entity.
+func TestClientDeleteSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/Feature/5678" {
+			t.Errorf("expected /api/v1/Feature/5678, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if err := client.Delete("Feature", "5678"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestClientDeleteInvalidID tests deletion with a non-numeric ID.
+func TestClientDeleteInvalidID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("server should not be called with invalid ID")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if err := client.Delete("Feature", "invalid-id"); err == nil {
+		t.Fatal("expected error for invalid ID format, got none")
+	}
+}
+
+// TestClientDeleteNotFound tests deletion when the entity doesn't exist.
+func TestClientDeleteNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		response := map[string]interface{}{
+			"Status":  "NotFound",
+			"Message": "Entity not found",
+			"Type":    "EntityNotFoundException",
+			"ErrorId": "789",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if err := client.Delete("Feature", "99999"); err == nil {
+		t.Fatal("expected error for not found, got none")
+	}
+}
+
+// TestClientDefaultAPIVersionIsV1 checks that requests use /api/v1 when
+// APIVersion is left at its NewClient default.
+func TestClientDefaultAPIVersionIsV1(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/Feature" {
+			t.Errorf("expected /api/v1/Feature, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Items":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.List("Feature", "", nil, 0, 0); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+}
+
+// TestClientWithAPIVersionOverridesDefault checks that WithAPIVersion pins
+// the version for a single request without touching Client.APIVersion.
+func TestClientWithAPIVersionOverridesDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2beta/Feature/42" {
+			t.Errorf("expected /api/v2beta/Feature/42, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.Get("Feature", 42, nil, WithAPIVersion("v2beta")); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if client.APIVersion != "v1" {
+		t.Errorf("expected client default APIVersion to remain v1, got %s", client.APIVersion)
+	}
+}
+
+// TestClientAPIVersionFieldChangesDefault checks that setting Client.APIVersion
+// changes the version used when no per-request WithAPIVersion is given.
+func TestClientAPIVersionFieldChangesDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/Feature/42" {
+			t.Errorf("expected /api/v3/Feature/42, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+	client.APIVersion = "v3"
+
+	if _, err := client.Get("Feature", 42, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+}
+
+// TestClientTransportUsesMockRoundTripper checks that setting Client.Transport
+// routes requests through an in-memory RoundTripper instead of a real
+// network connection.
+func TestClientTransportUsesMockRoundTripper(t *testing.T) {
+	var gotMethod, gotPath string
+	client := NewClient("http://example.invalid", "test-token", false)
+	client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		gotMethod = req.Method
+		gotPath = req.URL.Path
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":1}`))),
+		}, nil
+	})
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if gotMethod != "GET" {
+		t.Errorf("expected GET, got %s", gotMethod)
+	}
+	if gotPath != "/api/v1/Feature/1" {
+		t.Errorf("expected /api/v1/Feature/1, got %s", gotPath)
+	}
+}
@@ -0,0 +1,165 @@
+package tpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// listPage is a single page of List results: the entities themselves plus,
+// when the server has more to give, the Next link TargetProcess returns for
+// fetching the following page.
+type listPage struct {
+	Items []map[string]interface{} `json:"Items"`
+	Next  string                   `json:"Next"`
+}
+
+// listPagePath builds the path for a List/ListAll/ListStream page.
+func listPagePath(version, entityType, where string, fields []string, take, skip int) string {
+	params := url.Values{}
+
+	if where != "" {
+		params.Set("where", where)
+	}
+	if len(fields) > 0 {
+		params.Set("include", fmt.Sprintf("[%s]", strings.Join(fields, ",")))
+	}
+	if take > 0 {
+		params.Set("take", fmt.Sprintf("%d", take))
+	}
+	if skip > 0 {
+		params.Set("skip", fmt.Sprintf("%d", skip))
+	}
+
+	path := buildURL(version, entityType, "")
+	if len(params) > 0 {
+		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	}
+	return path
+}
+
+// fetchListPage executes a single List-style GET against path and decodes
+// the page, including its Next link.
+func (c *Client) fetchListPage(ctx context.Context, path string) (listPage, error) {
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return listPage{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		var apiErr struct {
+			Status  string `json:"Status"`
+			Message string `json:"Message"`
+			Type    string `json:"Type"`
+			ErrorId string `json:"ErrorId"`
+		}
+		msg := string(body)
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+			msg = fmt.Sprintf("%s: %s (%s)", apiErr.Status, apiErr.Message, apiErr.Type)
+		}
+		return listPage{}, fmt.Errorf("API error %d: %s", resp.StatusCode, msg)
+	}
+
+	var page listPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return listPage{}, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return page, nil
+}
+
+// ListAll retrieves every entity matching where, walking pages of pageSize
+// until the server runs out. It follows the Next link TargetProcess returns
+// when present, and otherwise advances skip by pageSize itself, stopping
+// once a page comes back with fewer than pageSize items. Unlike List, the
+// caller doesn't need to drive skip/take manually to dump an entire project.
+// It is a thin wrapper around ListAllContext using context.Background().
+func (c *Client) ListAll(entityType, where string, fields []string, pageSize int) ([]map[string]interface{}, error) {
+	return c.ListAllContext(context.Background(), entityType, where, fields, pageSize)
+}
+
+// ListAllContext behaves like ListAll, aborting if ctx is done before the
+// walk (including any page's retries) completes.
+func (c *Client) ListAllContext(ctx context.Context, entityType, where string, fields []string, pageSize int) ([]map[string]interface{}, error) {
+	var all []map[string]interface{}
+
+	path := listPagePath(c.apiVersion(), entityType, where, fields, pageSize, 0)
+	for {
+		page, err := c.fetchListPage(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Items...)
+
+		if len(page.Items) < pageSize {
+			return all, nil
+		}
+
+		if page.Next != "" {
+			path = page.Next
+			continue
+		}
+		path = listPagePath(c.apiVersion(), entityType, where, fields, pageSize, len(all))
+	}
+}
+
+// ListStream behaves like ListAll but yields entities onto the returned
+// channel as each page arrives rather than buffering the full result, so a
+// large export doesn't have to fit in memory. The error channel carries at
+// most one error and is only worth checking once items has been drained (and
+// so has closed); ctx cancellation surfaces there as ctx.Err().
+func (c *Client) ListStream(ctx context.Context, entityType, where string, fields []string, pageSize int) (<-chan map[string]interface{}, <-chan error) {
+	items := make(chan map[string]interface{})
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(items)
+		defer close(errc)
+
+		path := listPagePath(c.apiVersion(), entityType, where, fields, pageSize, 0)
+		seen := 0
+
+		for {
+			select {
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			default:
+			}
+
+			page, err := c.fetchListPage(ctx, path)
+			if err != nil {
+				errc <- err
+				return
+			}
+
+			for _, item := range page.Items {
+				select {
+				case items <- item:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+			seen += len(page.Items)
+
+			if len(page.Items) < pageSize {
+				return
+			}
+
+			if page.Next != "" {
+				path = page.Next
+				continue
+			}
+			path = listPagePath(c.apiVersion(), entityType, where, fields, pageSize, seen)
+		}
+	}()
+
+	return items, errc
+}
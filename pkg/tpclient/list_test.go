@@ -0,0 +1,194 @@
+package tpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestClientListAllFollowsNextLink checks that ListAll follows the server's
+// Next link rather than recomputing skip itself.
+func TestClientListAllFollowsNextLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("skip") {
+		case "":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Items": []map[string]interface{}{{"Id": 1}, {"Id": 2}},
+				"Next":  "/api/v1/Feature?skip=2&take=2&cursor=next",
+			})
+		case "2":
+			if r.URL.Query().Get("cursor") != "next" {
+				t.Errorf("expected the Next link to be followed, got %s", r.URL)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Items": []map[string]interface{}{{"Id": 3}},
+			})
+		default:
+			t.Fatalf("unexpected skip %q", r.URL.Query().Get("skip"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	items, err := client.ListAll("Feature", "", nil, 2)
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+}
+
+// TestClientListAllFollowsAbsoluteNextLink checks that ListAll follows an
+// absolute Next link (what TargetProcess actually returns) as-is, instead
+// of appending it to BaseURL and producing a malformed URL.
+func TestClientListAllFollowsAbsoluteNextLink(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("skip") {
+		case "":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Items": []map[string]interface{}{{"Id": 1}, {"Id": 2}},
+				"Next":  server.URL + "/api/v1/Feature?skip=2&take=2",
+			})
+		case "2":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Items": []map[string]interface{}{{"Id": 3}},
+			})
+		default:
+			t.Fatalf("unexpected skip %q", r.URL.Query().Get("skip"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	items, err := client.ListAll("Feature", "", nil, 2)
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+}
+
+// TestClientListAllIncrementsSkipWithoutNext checks that ListAll falls back
+// to advancing skip itself when the server omits a Next link.
+func TestClientListAllIncrementsSkipWithoutNext(t *testing.T) {
+	pages := [][]map[string]interface{}{
+		{{"Id": 1}, {"Id": 2}},
+		{{"Id": 3}},
+	}
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls >= len(pages) {
+			t.Fatalf("unexpected extra page request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"Items": pages[calls]})
+		calls++
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	items, err := client.ListAll("Feature", "", nil, 2)
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 page requests, got %d", calls)
+	}
+}
+
+// TestClientListAllPropagatesPageError checks that a failed page aborts the
+// walk and surfaces the error.
+func TestClientListAllPropagatesPageError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Message": "boom"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.ListAll("Feature", "", nil, 2); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+// TestClientListStreamYieldsItemsAcrossPages checks that ListStream delivers
+// items from every page without buffering, and closes both channels cleanly.
+func TestClientListStreamYieldsItemsAcrossPages(t *testing.T) {
+	pages := [][]map[string]interface{}{
+		{{"Id": 1}, {"Id": 2}},
+		{{"Id": 3}},
+	}
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"Items": pages[calls]})
+		calls++
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	items, errc := client.ListStream(context.Background(), "Feature", "", nil, 2)
+
+	var got []map[string]interface{}
+	for item := range items {
+		got = append(got, item)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(got))
+	}
+}
+
+// TestClientListStreamStopsOnContextCancel checks that ListStream aborts
+// promptly and reports ctx.Err() once its context is cancelled mid-stream.
+func TestClientListStreamStopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		page := make([]map[string]interface{}, 0, 50)
+		for i := 0; i < 50; i++ {
+			page = append(page, map[string]interface{}{"Id": i})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"Items": page})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	items, errc := client.ListStream(ctx, "Feature", "", nil, 50)
+
+	<-items
+	cancel()
+	for range items {
+		// drain until the producer notices cancellation and closes items
+	}
+
+	select {
+	case err := <-errc:
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for error channel")
+	}
+}
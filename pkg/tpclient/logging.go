@@ -0,0 +1,127 @@
+package tpclient
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LevelTrace is a custom slog level, one step noisier than slog.LevelDebug,
+// for logging full (redacted) request/response bodies.
+const LevelTrace = slog.LevelDebug - 4
+
+// ParseLogLevel parses a --log-level value (error, warn, info, debug or
+// trace, case-insensitive) into a slog.Level.
+func ParseLogLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "error":
+		return slog.LevelError, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "trace":
+		return LevelTrace, nil
+	default:
+		return 0, &unknownLogLevelError{s}
+	}
+}
+
+type unknownLogLevelError struct{ level string }
+
+func (e *unknownLogLevelError) Error() string {
+	return "unknown log level " + e.level + ": want error, warn, info, debug or trace"
+}
+
+// NewLogger builds a slog.Logger writing to stderr at level, formatted as
+// "text" or "json".
+func NewLogger(level slog.Level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if format == "json" {
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	}
+	return slog.New(handler)
+}
+
+// redactQuery replaces an access_token query parameter's value with
+// "REDACTED", so logged URLs are safe to paste into a support ticket.
+var redactQuery = regexp.MustCompile(`access_token=[^&\s]+`)
+
+// redactBody replaces access_token values embedded in a JSON body with
+// "REDACTED".
+var redactBody = regexp.MustCompile(`("access_token"\s*:\s*")[^"]*(")`)
+
+func redact(s string) string {
+	s = redactQuery.ReplaceAllString(s, "access_token=REDACTED")
+	s = redactBody.ReplaceAllString(s, "${1}REDACTED${2}")
+	return s
+}
+
+// LoggingMiddleware logs each request at Debug level (method, redacted URL,
+// status, elapsed time and response size) and, at Trace level, also the
+// redacted request and response bodies. It replaces the ad-hoc fmt.Printf
+// calls doRequest used to make directly, and is rebuilt fresh against
+// Client.Logger on every call to transport() so SetLogLevel/SetLogFormat
+// take effect immediately.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next RoundTripper) RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			if !logger.Enabled(ctx, slog.LevelDebug) {
+				return next.RoundTrip(req)
+			}
+
+			requestID := req.Header.Get("X-Request-Id")
+			trace := logger.Enabled(ctx, LevelTrace)
+
+			var reqBody []byte
+			if trace && req.Body != nil {
+				reqBody, _ = io.ReadAll(req.Body)
+				req.Body = io.NopCloser(bytes.NewReader(reqBody))
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			elapsed := time.Since(start)
+
+			attrs := []any{
+				"method", req.Method,
+				"url", redact(req.URL.String()),
+				"elapsed", elapsed,
+			}
+			if requestID != "" {
+				attrs = append(attrs, "request_id", requestID)
+			}
+			if trace && len(reqBody) > 0 {
+				attrs = append(attrs, "request_body", redact(string(reqBody)))
+			}
+
+			if err != nil {
+				logger.Log(ctx, slog.LevelDebug, "tpclient request failed", append(attrs, "error", err)...)
+				return resp, err
+			}
+
+			attrs = append(attrs, "status", resp.StatusCode, "bytes", resp.ContentLength)
+
+			if trace {
+				respBody, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(respBody))
+				attrs = append(attrs, "response_body", redact(string(respBody)))
+			}
+
+			logger.Log(ctx, slog.LevelDebug, "tpclient request", attrs...)
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package tpclient
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	cases := map[string]slog.Level{
+		"error":   slog.LevelError,
+		"warn":    slog.LevelWarn,
+		"warning": slog.LevelWarn,
+		"info":    slog.LevelInfo,
+		"debug":   slog.LevelDebug,
+		"trace":   LevelTrace,
+		"DEBUG":   slog.LevelDebug,
+	}
+	for s, want := range cases {
+		got, err := ParseLogLevel(s)
+		if err != nil {
+			t.Errorf("ParseLogLevel(%q): unexpected error: %v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseLogLevel(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestParseLogLevelUnknown(t *testing.T) {
+	if _, err := ParseLogLevel("bogus"); err == nil {
+		t.Error("expected error for unknown log level, got none")
+	}
+}
+
+func TestRedactQueryToken(t *testing.T) {
+	got := redact("https://example.com/api/v1/Bugs?access_token=super-secret&format=json")
+	if strings.Contains(got, "super-secret") {
+		t.Errorf("expected access_token to be redacted, got %q", got)
+	}
+	if !strings.Contains(got, "access_token=REDACTED") {
+		t.Errorf("expected redacted marker, got %q", got)
+	}
+}
+
+func TestRedactBodyToken(t *testing.T) {
+	got := redact(`{"access_token":"super-secret","name":"foo"}`)
+	if strings.Contains(got, "super-secret") {
+		t.Errorf("expected access_token to be redacted, got %q", got)
+	}
+	if !strings.Contains(got, `"access_token":"REDACTED"`) {
+		t.Errorf("expected redacted marker, got %q", got)
+	}
+}
+
+func TestLoggingMiddlewareLogsAtDebugNotInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(server.URL, "test-token", false)
+	client.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no log output at LevelInfo, got %q", buf.String())
+	}
+}
+
+func TestLoggingMiddlewareLogsRequestAtDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(server.URL, "test-token", false)
+	client.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "tpclient request") {
+		t.Errorf("expected a logged request line, got %q", out)
+	}
+	if strings.Contains(out, "request_body") || strings.Contains(out, "response_body") {
+		t.Errorf("expected no bodies logged at LevelDebug, got %q", out)
+	}
+}
+
+func TestLoggingMiddlewareRedactsBodiesAtTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1,"access_token":"super-secret"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(server.URL, "test-token", false)
+	client.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: LevelTrace}))
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "super-secret") {
+		t.Errorf("expected response body access_token to be redacted, got %q", out)
+	}
+	if !strings.Contains(out, "response_body") {
+		t.Errorf("expected response_body attribute at LevelTrace, got %q", out)
+	}
+}
@@ -0,0 +1,79 @@
+package tpclient
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+// RoundTripper is an alias for http.RoundTripper, kept local so middleware
+// signatures read naturally alongside the rest of this package.
+type RoundTripper = http.RoundTripper
+
+// Middleware wraps a RoundTripper to observe or modify requests and
+// responses around it, in the style of net/http's own handler chaining.
+type Middleware func(next RoundTripper) RoundTripper
+
+// roundTripperFunc adapts a function to a RoundTripper.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use registers a Middleware to be applied to every request. Middlewares
+// run in the order they were added, with the first added being outermost.
+func (c *Client) Use(mw Middleware) {
+	c.middlewares = append(c.middlewares, mw)
+}
+
+// transport builds the RoundTripper for a request by wrapping the client's
+// base Transport (or http.DefaultTransport, if unset) with retry/backoff,
+// rate limiting and logging, then with each registered middleware. Retry and
+// rate limiting sit innermost, closest to the network, so a registered
+// middleware sees one request/response pair per call, with retries resolved
+// underneath it; logging sits just outside them so it reports the outcome
+// after retries are exhausted, but still inside RequestIDMiddleware (which
+// NewClient registers via Use) so a logged request carries its request ID.
+// LoggingMiddleware and rateLimitTransport are rebuilt on every call, rather
+// than registered once, so SetLogLevel/SetLogFormat/SetRateLimit take effect
+// on the client's very next request.
+func (c *Client) transport() RoundTripper {
+	var base RoundTripper = c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	base = retryTransport(base, c.MaxRetries)
+	base = rateLimitTransport(base, c.RateLimiter)
+	base = LoggingMiddleware(c.Logger)(base)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		base = c.middlewares[i](base)
+	}
+	return base
+}
+
+// RequestIDMiddleware sets an X-Request-Id header on every request that
+// doesn't already have one, to correlate client requests with server logs.
+func RequestIDMiddleware() Middleware {
+	return func(next RoundTripper) RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Request-Id") == "" {
+				id, err := newRequestID()
+				if err != nil {
+					return nil, fmt.Errorf("generating request id: %w", err)
+				}
+				req.Header.Set("X-Request-Id", id)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func newRequestID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,72 @@
+package tpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientUseAppliesMiddlewareOutermostFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripper) RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	client := NewClient(server.URL, "test-token", false)
+	client.Use(trace("first"))
+	client.Use(trace("second"))
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("expected middlewares to run in registration order [first second], got %v", order)
+	}
+}
+
+func TestRequestIDMiddlewareSetsHeaderWhenAbsent(t *testing.T) {
+	var gotID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = r.Header.Get("X-Request-Id")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+	client.Use(RequestIDMiddleware())
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if gotID == "" {
+		t.Error("expected X-Request-Id header to be set")
+	}
+}
+
+func TestLoggingMiddlewareNoopWhenDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+}
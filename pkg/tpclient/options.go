@@ -0,0 +1,59 @@
+package tpclient
+
+import "time"
+
+// requestConfig holds the per-request settings assembled from Option values.
+type requestConfig struct {
+	idempotencyKey string
+	timeout        time.Duration
+	headers        map[string]string
+	concurrency    int
+	apiVersion     string
+}
+
+// Option configures a single request (Create, Update, ...) via the
+// functional-options pattern.
+type Option func(*requestConfig)
+
+// WithIdempotencyKey sets an explicit Idempotency-Key header on the request,
+// overriding the key tpclient would otherwise derive automatically.
+func WithIdempotencyKey(key string) Option {
+	return func(rc *requestConfig) {
+		rc.idempotencyKey = key
+	}
+}
+
+// WithTimeout overrides the client's default HTTP timeout for a single request.
+func WithTimeout(d time.Duration) Option {
+	return func(rc *requestConfig) {
+		rc.timeout = d
+	}
+}
+
+// WithHeader sets an arbitrary header on the request, in addition to the
+// headers tpclient sets by default (Accept, Content-Type, Idempotency-Key).
+func WithHeader(key, value string) Option {
+	return func(rc *requestConfig) {
+		if rc.headers == nil {
+			rc.headers = make(map[string]string)
+		}
+		rc.headers[key] = value
+	}
+}
+
+// WithConcurrency bounds the worker pool used by BulkCreate and BulkUpdate.
+// It has no effect on a single Create/Update call. The default is 1
+// (sequential) when unset.
+func WithConcurrency(n int) Option {
+	return func(rc *requestConfig) {
+		rc.concurrency = n
+	}
+}
+
+// WithAPIVersion pins the API version (e.g. "v2beta") for a single request,
+// overriding the client's default Client.APIVersion.
+func WithAPIVersion(version string) Option {
+	return func(rc *requestConfig) {
+		rc.apiVersion = version
+	}
+}
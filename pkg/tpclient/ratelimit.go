@@ -0,0 +1,89 @@
+package tpclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token-bucket limiter that bounds how many requests a
+// Client issues per second, so a bulk discover or ListAll run doesn't get
+// throttled by TargetProcess itself. A nil *RateLimiter applies no limit.
+type RateLimiter struct {
+	mu         sync.Mutex
+	tokens     float64
+	burst      float64
+	rps        float64
+	lastRefill time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing up to rps requests per
+// second on average, with bursts up to burst requests.
+func NewRateLimiter(rps, burst float64) *RateLimiter {
+	return &RateLimiter{
+		tokens:     burst,
+		burst:      burst,
+		rps:        rps,
+		lastRefill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. A nil receiver
+// always returns immediately, so an unconfigured Client.RateLimiter is a
+// no-op.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// reserve refills the bucket for elapsed time, consumes a token if one is
+// available, and otherwise returns how long the caller should wait.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.lastRefill).Seconds() * l.rps
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.lastRefill = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	missing := 1 - l.tokens
+	return time.Duration(missing / l.rps * float64(time.Second))
+}
+
+// rateLimitTransport wraps next so that every request waits for limiter
+// before being sent. A nil limiter disables rate limiting entirely.
+func rateLimitTransport(next RoundTripper, limiter *RateLimiter) RoundTripper {
+	if limiter == nil {
+		return next
+	}
+
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		return next.RoundTrip(req)
+	})
+}
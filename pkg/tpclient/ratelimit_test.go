@@ -0,0 +1,58 @@
+package tpclient
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllowsBurstWithoutWaiting(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+			t.Errorf("expected burst token %d to be immediate, took %v", i, elapsed)
+		}
+	}
+}
+
+func TestRateLimiterThrottlesBeyondBurst(t *testing.T) {
+	l := NewRateLimiter(20, 1)
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected second call to wait for a refill, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterNilIsNoop(t *testing.T) {
+	var l *RateLimiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("expected nil limiter to be a no-op, got %v", err)
+	}
+}
+
+func TestRateLimiterRespectsContextCancellation(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.Wait(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
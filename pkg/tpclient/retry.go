@@ -0,0 +1,82 @@
+package tpclient
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport wraps next so that a request is retried up to maxRetries
+// times after a network error, a 429 Too Many Requests, or a 503 Service
+// Unavailable. It honors the server's Retry-After header when present, and
+// otherwise backs off exponentially with jitter. maxRetries <= 0 disables
+// retries entirely.
+func retryTransport(next RoundTripper, maxRetries int) RoundTripper {
+	if maxRetries <= 0 {
+		return next
+	}
+
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		for attempt := 0; ; attempt++ {
+			resp, err := next.RoundTrip(req)
+
+			retryable := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+			if !retryable || attempt >= maxRetries {
+				return resp, err
+			}
+
+			wait := retryAfter(resp)
+			if wait <= 0 {
+				wait = backoff(attempt)
+			}
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(wait):
+			}
+
+			// The previous attempt consumed the body; rewind it for the retry.
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+	})
+}
+
+// retryAfter parses a response's Retry-After header, which TargetProcess
+// sends as either a number of seconds or an HTTP date. It returns 0 if resp
+// is nil or the header is absent or unparsable, so the caller falls back to
+// backoff.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
+
+// backoff returns an exponential delay for the given 0-indexed attempt,
+// starting at 100ms and doubling each time, with up to 100% jitter added so
+// concurrent requests retrying together don't all land on the same instant.
+func backoff(attempt int) time.Duration {
+	base := 100 * time.Millisecond << uint(attempt)
+	return base + time.Duration(rand.Int63n(int64(base)+1))
+}
@@ -0,0 +1,131 @@
+package tpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterParsesSeconds(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if got := retryAfter(resp); got != 5*time.Second {
+		t.Errorf("expected 5s, got %v", got)
+	}
+}
+
+func TestRetryAfterReturnsZeroWhenAbsent(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if got := retryAfter(resp); got != 0 {
+		t.Errorf("expected 0, got %v", got)
+	}
+}
+
+func TestRetryAfterReturnsZeroForNilResponse(t *testing.T) {
+	if got := retryAfter(nil); got != 0 {
+		t.Errorf("expected 0, got %v", got)
+	}
+}
+
+func TestClientRetriesOn503ThenSucceeds(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 calls (1 retry), got %d", got)
+	}
+}
+
+func TestClientRetriesOn429(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.Get("Feature", 1, nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 calls (1 retry), got %d", got)
+	}
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+	client.MaxRetries = 2
+
+	if _, err := client.Get("Feature", 1, nil); err == nil {
+		t.Fatal("expected error after exhausting retries, got none")
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 calls (1 initial + 2 retries), got %d", got)
+	}
+}
+
+func TestClientMaxRetriesZeroDisablesRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+	client.MaxRetries = 0
+
+	if _, err := client.Get("Feature", 1, nil); err == nil {
+		t.Fatal("expected error, got none")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected exactly 1 call with retries disabled, got %d", got)
+	}
+}
+
+func TestClientDoesNotRetryOnNon200NonRetryableStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if _, err := client.Get("Feature", 1, nil); err == nil {
+		t.Fatal("expected error, got none")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected exactly 1 call for a non-retryable status, got %d", got)
+	}
+}
@@ -0,0 +1,74 @@
+package tpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ServerInfo describes the TargetProcess instance behind Client.BaseURL, as
+// reported by its Context endpoint: the server's version and the identity
+// of the credentials the client authenticated with.
+type ServerInfo struct {
+	Version  string `json:"Version"`
+	Identity string `json:"Identity"`
+}
+
+// ServerInfo probes the API's Context endpoint and reports the server's
+// version and the authenticated identity, confirming both connectivity and
+// that the client's credentials are valid. It is a thin wrapper around
+// ServerInfoContext using context.Background().
+func (c *Client) ServerInfo() (ServerInfo, error) {
+	return c.ServerInfoContext(context.Background())
+}
+
+// ServerInfoContext behaves like ServerInfo, aborting if ctx is done before
+// the request (including its retries) completes.
+func (c *Client) ServerInfoContext(ctx context.Context) (ServerInfo, error) {
+	path := buildURL(c.apiVersion(), "Context", "")
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return ServerInfo{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		var apiErr struct {
+			Status  string `json:"Status"`
+			Message string `json:"Message"`
+			Type    string `json:"Type"`
+			ErrorId string `json:"ErrorId"`
+		}
+		msg := string(body)
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+			msg = fmt.Sprintf("%s: %s (%s)", apiErr.Status, apiErr.Message, apiErr.Type)
+		}
+		return ServerInfo{}, fmt.Errorf("API error %d: %s", resp.StatusCode, msg)
+	}
+
+	var info ServerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return ServerInfo{}, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return info, nil
+}
+
+// Ping confirms the client can reach the server and authenticate
+// successfully, discarding the ServerInfo that ServerInfo would otherwise
+// return. It is a thin wrapper around PingContext using
+// context.Background().
+func (c *Client) Ping() error {
+	return c.PingContext(context.Background())
+}
+
+// PingContext behaves like Ping, aborting if ctx is done before the request
+// (including its retries) completes.
+func (c *Client) PingContext(ctx context.Context) error {
+	_, err := c.ServerInfoContext(ctx)
+	return err
+}
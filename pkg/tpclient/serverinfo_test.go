@@ -0,0 +1,70 @@
+package tpclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestClientServerInfoSuccess checks that ServerInfo decodes the Context
+// endpoint's response.
+func TestClientServerInfoSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/Context" {
+			t.Errorf("expected /api/v1/Context, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"Version":  "3.25.1",
+			"Identity": "svc-account@example.com",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	info, err := client.ServerInfo()
+	if err != nil {
+		t.Fatalf("ServerInfo: %v", err)
+	}
+	if info.Version != "3.25.1" || info.Identity != "svc-account@example.com" {
+		t.Errorf("unexpected ServerInfo: %+v", info)
+	}
+}
+
+// TestClientServerInfoAuthFailure checks that ServerInfo surfaces the API's
+// error on an authentication failure.
+func TestClientServerInfoAuthFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"Status":  "Error",
+			"Message": "Invalid token",
+			"Type":    "AuthenticationException",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "bad-token", false)
+
+	if _, err := client.ServerInfo(); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+// TestClientPingSucceedsWhenServerInfoSucceeds checks that Ping mirrors
+// ServerInfo's success/failure outcome without returning its value.
+func TestClientPingSucceedsWhenServerInfoSucceeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"Version": "3.25.1"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", false)
+
+	if err := client.Ping(); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+}